@@ -0,0 +1,108 @@
+package achievements
+
+import "quizz-ssh/models"
+
+// Achievement décrit un badge à débloquer
+type Achievement struct {
+	Code        string
+	Icon        string
+	Name        string
+	Description string
+	check       func(Context) bool
+}
+
+// Context contient l'état d'un joueur juste après l'enregistrement d'une partie
+type Context struct {
+	Score      models.Score     // Partie qui vient d'être enregistrée
+	Stats      models.UserStats // Statistiques cumulées, partie incluse
+	Streak     int              // Nombre de jours consécutifs joués
+	Categories []string         // Catégories de la banque de questions
+
+	// Meilleur taux de bonnes réponses sur une partie, par catégorie de
+	// question: une partie mêle les catégories de la banque
+	CategoryRates map[string]float64
+}
+
+// All liste les badges dans leur ordre d'affichage
+var All = []Achievement{
+	{
+		Code:        "first_perfect",
+		Icon:        "🏆",
+		Name:        "Sans faute",
+		Description: "Obtenir 100% à un quiz",
+		check: func(c Context) bool {
+			return c.Score.Total > 0 && c.Score.Score == c.Score.Total
+		},
+	},
+	{
+		Code:        "streak_10",
+		Icon:        "🔥",
+		Name:        "Assidu",
+		Description: "Jouer 10 jours d'affilée",
+		check: func(c Context) bool {
+			return c.Streak >= 10
+		},
+	},
+	{
+		Code:        "all_categories_80",
+		Icon:        "🎓",
+		Name:        "Polyvalent",
+		Description: "Dépasser 80% dans toutes les catégories",
+		check: func(c Context) bool {
+			if len(c.Categories) == 0 {
+				return false
+			}
+			for _, cat := range c.Categories {
+				if c.CategoryRates[cat] < 0.8 {
+					return false
+				}
+			}
+			return true
+		},
+	},
+	{
+		Code:        "questions_500",
+		Icon:        "📚",
+		Name:        "Encyclopédie",
+		Description: "Répondre à 500 questions",
+		check: func(c Context) bool {
+			return c.Stats.Questions >= 500
+		},
+	},
+}
+
+// Lookup retrouve un badge par son code
+func Lookup(code string) (Achievement, bool) {
+	for _, a := range All {
+		if a.Code == code {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
+
+// Evaluate retourne les badges nouvellement remplis, hors ceux déjà débloqués
+func Evaluate(c Context, unlocked map[string]bool) []Achievement {
+	var earned []Achievement
+	for _, a := range All {
+		if !unlocked[a.Code] && a.check(c) {
+			earned = append(earned, a)
+		}
+	}
+	return earned
+}
+
+// Icons concatène les icônes des badges débloqués, dans l'ordre d'affichage
+func Icons(codes []string) string {
+	has := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		has[code] = true
+	}
+	var icons string
+	for _, a := range All {
+		if has[a.Code] {
+			icons += a.Icon
+		}
+	}
+	return icons
+}
@@ -0,0 +1,107 @@
+package achievements
+
+import (
+	"quizz-ssh/models"
+	"testing"
+)
+
+func codes(earned []Achievement) map[string]bool {
+	got := make(map[string]bool, len(earned))
+	for _, a := range earned {
+		got[a.Code] = true
+	}
+	return got
+}
+
+func TestEvaluate(t *testing.T) {
+	categories := []string{"Cryptographie", "Réseau"}
+	tests := []struct {
+		name string
+		ctx  Context
+		want []string
+	}{
+		{
+			name: "partie parfaite",
+			ctx:  Context{Score: models.Score{Score: 10, Total: 10}},
+			want: []string{"first_perfect"},
+		},
+		{
+			name: "partie vide",
+			ctx:  Context{Score: models.Score{Score: 0, Total: 0}},
+		},
+		{
+			name: "dix jours d'affilée",
+			ctx:  Context{Score: models.Score{Score: 1, Total: 10}, Streak: 10},
+			want: []string{"streak_10"},
+		},
+		{
+			name: "neuf jours d'affilée",
+			ctx:  Context{Score: models.Score{Score: 1, Total: 10}, Streak: 9},
+		},
+		{
+			name: "500 questions",
+			ctx:  Context{Score: models.Score{Score: 1, Total: 10}, Stats: models.UserStats{Questions: 500}},
+			want: []string{"questions_500"},
+		},
+		{
+			name: "80% dans chacune de plusieurs catégories",
+			ctx: Context{
+				Score:         models.Score{Score: 9, Total: 10},
+				Categories:    categories,
+				CategoryRates: map[string]float64{"Cryptographie": 0.8, "Réseau": 0.9},
+			},
+			want: []string{"all_categories_80"},
+		},
+		{
+			name: "une catégorie sous 80%",
+			ctx: Context{
+				Score:         models.Score{Score: 9, Total: 10},
+				Categories:    categories,
+				CategoryRates: map[string]float64{"Cryptographie": 1, "Réseau": 0.75},
+			},
+		},
+		{
+			name: "une catégorie jamais jouée",
+			ctx: Context{
+				Score:         models.Score{Score: 9, Total: 10},
+				Categories:    categories,
+				CategoryRates: map[string]float64{"Cryptographie": 1},
+			},
+		},
+		{
+			name: "banque vide",
+			ctx: Context{
+				Score:         models.Score{Score: 9, Total: 10},
+				CategoryRates: map[string]float64{"Cryptographie": 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := codes(Evaluate(tt.ctx, nil))
+			if len(got) != len(tt.want) {
+				t.Errorf("badges %v, attendu %v", got, tt.want)
+			}
+			for _, code := range tt.want {
+				if !got[code] {
+					t.Errorf("badge %s non débloqué (badges: %v)", code, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateSkipsUnlocked(t *testing.T) {
+	ctx := Context{Score: models.Score{Score: 10, Total: 10}, Streak: 10}
+	got := codes(Evaluate(ctx, map[string]bool{"first_perfect": true}))
+	if got["first_perfect"] || !got["streak_10"] {
+		t.Errorf("badges %v, attendu seulement streak_10", got)
+	}
+}
+
+func TestIcons(t *testing.T) {
+	// Ordre d'affichage de All, quel que soit l'ordre des codes; codes inconnus ignorés
+	if got := Icons([]string{"questions_500", "inconnu", "first_perfect"}); got != "🏆📚" {
+		t.Errorf("Icons() = %q, attendu %q", got, "🏆📚")
+	}
+}
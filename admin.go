@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// adminKeysPath liste les clés SSH des administrateurs, au format authorized_keys
+const adminKeysPath = "./data/admin_keys"
+
+// adminKeys est chargée au démarrage depuis ADMIN_KEYS_FILE (ou adminKeysPath)
+var adminKeys []ssh.PublicKey
+
+// loadAdminKeys lit un fichier authorized_keys; un fichier absent signifie aucun administrateur
+func loadAdminKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []ssh.PublicKey
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("%s ligne %d: %w", path, n, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, scanner.Err()
+}
+
+// isAdmin indique si la session s'est authentifiée avec une clé d'administrateur
+func isAdmin(s ssh.Session) bool {
+	key := s.PublicKey()
+	if key == nil {
+		return false
+	}
+	for _, admin := range adminKeys {
+		if ssh.KeysEqual(key, admin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package analytics
+
+import (
+	"fmt"
+	"quizz-ssh/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MinAnswers est le nombre de réponses en dessous duquel une question n'est pas jugée
+	MinAnswers = 10
+
+	// Seuils de calibrage de la difficulté (taux de réussite)
+	tooEasyRate = 0.90
+	tooHardRate = 0.20
+
+	// Une mauvaise réponse choisie par moins de 5% des joueurs ne trompe personne
+	deadDistractorRate = 0.05
+
+	// Part des joueurs dans chaque groupe (meilleurs / moins bons) pour l'indice de discrimination
+	groupShare = 0.27
+)
+
+// Flag signale un défaut de calibrage d'une question
+type Flag string
+
+const (
+	FlagTooEasy        Flag = "trop facile"
+	FlagTooHard        Flag = "trop difficile"
+	FlagDeadDistractor Flag = "distracteur mort"
+)
+
+// QuestionStats est l'analyse d'une question, sur les réponses à sa version courante
+type QuestionStats struct {
+	Question       models.Question
+	Answers        int
+	Correct        int
+	SuccessRate    float64       // 0 à 1
+	AvgTime        time.Duration // temps de réponse moyen
+	Discrimination float64       // -1 à 1: réussite des meilleurs joueurs moins celle des moins bons
+	OptionCounts   []int         // nombre de choix par option (ordre d'origine)
+	DeadOptions    []int         // mauvaises réponses (presque) jamais choisies
+	Flags          []Flag
+}
+
+// Judged indique si la question a assez de réponses pour être jugée
+func (s QuestionStats) Judged() bool {
+	return s.Answers >= MinAnswers
+}
+
+// OptionRate retourne la part des réponses ayant choisi l'option i
+func (s QuestionStats) OptionRate(i int) float64 {
+	if s.Answers == 0 || i < 0 || i >= len(s.OptionCounts) {
+		return 0
+	}
+	return float64(s.OptionCounts[i]) / float64(s.Answers)
+}
+
+// Analyze calcule les statistiques de chaque question à partir des réponses enregistrées.
+// Seules les réponses à la version courante comptent: corriger une question repart de zéro.
+func Analyze(questions []models.Question, answers []models.RecordedAnswer) []QuestionStats {
+	playerRates := playerSuccessRates(answers)
+
+	byQuestion := make(map[int][]models.RecordedAnswer)
+	for _, a := range answers {
+		byQuestion[a.QuestionID] = append(byQuestion[a.QuestionID], a)
+	}
+
+	stats := make([]QuestionStats, 0, len(questions))
+	for _, q := range questions {
+		var current []models.RecordedAnswer
+		for _, a := range byQuestion[q.ID] {
+			if a.Revision == q.Revision {
+				current = append(current, a)
+			}
+		}
+		stats = append(stats, analyzeQuestion(q, current, playerRates))
+	}
+	return stats
+}
+
+func analyzeQuestion(q models.Question, answers []models.RecordedAnswer, playerRates map[string]float64) QuestionStats {
+	s := QuestionStats{
+		Question:     q,
+		Answers:      len(answers),
+		OptionCounts: make([]int, len(q.Options)),
+	}
+	if len(answers) == 0 {
+		return s
+	}
+
+	var total time.Duration
+	for _, a := range answers {
+		if a.Correct {
+			s.Correct++
+		}
+		total += a.Duration
+		if a.Chosen >= 0 && a.Chosen < len(s.OptionCounts) {
+			s.OptionCounts[a.Chosen]++
+		}
+	}
+	s.SuccessRate = float64(s.Correct) / float64(s.Answers)
+	s.AvgTime = total / time.Duration(s.Answers)
+	s.Discrimination = discrimination(answers, playerRates)
+
+	for i := range q.Options {
+		if i != q.Answer && s.OptionRate(i) < deadDistractorRate {
+			s.DeadOptions = append(s.DeadOptions, i)
+		}
+	}
+
+	if s.Judged() {
+		switch {
+		case s.SuccessRate > tooEasyRate:
+			s.Flags = append(s.Flags, FlagTooEasy)
+		case s.SuccessRate < tooHardRate:
+			s.Flags = append(s.Flags, FlagTooHard)
+		}
+		if len(s.DeadOptions) > 0 {
+			s.Flags = append(s.Flags, FlagDeadDistractor)
+		}
+	}
+	return s
+}
+
+// playerSuccessRates retourne le taux de bonnes réponses de chaque joueur, toutes questions confondues
+func playerSuccessRates(answers []models.RecordedAnswer) map[string]float64 {
+	correct := make(map[string]int)
+	count := make(map[string]int)
+	for _, a := range answers {
+		count[a.Username]++
+		if a.Correct {
+			correct[a.Username]++
+		}
+	}
+
+	rates := make(map[string]float64, len(count))
+	for username, n := range count {
+		rates[username] = float64(correct[username]) / float64(n)
+	}
+	return rates
+}
+
+// discrimination compare la réussite à la question des 27% meilleurs joueurs
+// et des 27% moins bons parmi ceux qui y ont répondu (indice de Kelley)
+func discrimination(answers []models.RecordedAnswer, playerRates map[string]float64) float64 {
+	// Une seule réponse par joueur: la plus récente
+	latest := make(map[string]models.RecordedAnswer)
+	for _, a := range answers {
+		latest[a.Username] = a
+	}
+	players := make([]string, 0, len(latest))
+	for username := range latest {
+		players = append(players, username)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if playerRates[players[i]] != playerRates[players[j]] {
+			return playerRates[players[i]] > playerRates[players[j]]
+		}
+		return players[i] < players[j]
+	})
+
+	size := int(float64(len(players)) * groupShare)
+	if size == 0 {
+		return 0
+	}
+	return groupRate(players[:size], latest) - groupRate(players[len(players)-size:], latest)
+}
+
+func groupRate(players []string, latest map[string]models.RecordedAnswer) float64 {
+	correct := 0
+	for _, username := range players {
+		if latest[username].Correct {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(players))
+}
+
+// Flagged retourne les questions jugées présentant au moins un défaut
+func Flagged(stats []QuestionStats) []QuestionStats {
+	var flagged []QuestionStats
+	for _, s := range stats {
+		if len(s.Flags) > 0 {
+			flagged = append(flagged, s)
+		}
+	}
+	return flagged
+}
+
+// Summary décrit les défauts d'une question, avec les lettres des distracteurs morts
+func (s QuestionStats) Summary() string {
+	var parts []string
+	for _, f := range s.Flags {
+		if f != FlagDeadDistractor {
+			parts = append(parts, string(f))
+			continue
+		}
+		letters := make([]string, len(s.DeadOptions))
+		for i, opt := range s.DeadOptions {
+			letters[i] = string(rune('A' + opt))
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", f, strings.Join(letters, ", ")))
+	}
+	return strings.Join(parts, " • ")
+}
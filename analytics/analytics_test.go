@@ -0,0 +1,149 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"quizz-ssh/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// answer construit la réponse de username à la question 1, version 1
+func answer(username string, chosen int, correct bool) models.RecordedAnswer {
+	return models.RecordedAnswer{
+		Answer:   models.Answer{QuestionID: 1, Revision: 1, Chosen: chosen, Correct: correct, Duration: time.Second},
+		Username: username,
+	}
+}
+
+// ranked retourne n joueurs "p00", "p01"... du meilleur au moins bon, et leurs taux
+func ranked(n int) ([]string, map[string]float64) {
+	players := make([]string, n)
+	rates := make(map[string]float64, n)
+	for i := range players {
+		players[i] = fmt.Sprintf("p%02d", i)
+		rates[players[i]] = 1 - float64(i)/float64(n)
+	}
+	return players, rates
+}
+
+func TestDiscrimination(t *testing.T) {
+	tests := []struct {
+		name    string
+		players int
+		correct func(rank int) bool // rank 0: meilleur joueur
+		want    float64
+	}{
+		{"trop peu de joueurs pour former des groupes", 3, func(int) bool { return true }, 0},
+		{"groupes d'un joueur dès 4 joueurs", 4, func(rank int) bool { return rank == 0 }, 1},
+		{"les meilleurs réussissent, les moins bons échouent", 10, func(rank int) bool { return rank < 5 }, 1},
+		{"question réussie par tous", 10, func(int) bool { return true }, 0},
+		{"les moins bons réussissent mieux", 10, func(rank int) bool { return rank >= 5 }, -1},
+		// 27% de 20 joueurs: groupes de 5, dont 4 et 1 réussissent
+		{"écart partiel", 20, func(rank int) bool { return rank < 4 || rank == 19 }, 0.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players, rates := ranked(tt.players)
+			var answers []models.RecordedAnswer
+			for rank, username := range players {
+				answers = append(answers, answer(username, 0, tt.correct(rank)))
+			}
+			if got := discrimination(answers, rates); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("discrimination() = %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscriminationLatestAnswer(t *testing.T) {
+	players, rates := ranked(4)
+	answers := []models.RecordedAnswer{
+		answer(players[0], 0, false),
+		answer(players[0], 0, true), // Seule la plus récente compte
+		answer(players[1], 0, true),
+		answer(players[2], 0, true),
+		answer(players[3], 1, false),
+	}
+	if got := discrimination(answers, rates); got != 1 {
+		t.Errorf("discrimination() = %v, attendu 1", got)
+	}
+}
+
+func TestDiscriminationTies(t *testing.T) {
+	// À taux égal, l'ordre alphabétique départage: "a" est dans le groupe du haut
+	rates := map[string]float64{"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5}
+	answers := []models.RecordedAnswer{
+		answer("d", 0, false), answer("c", 0, false), answer("b", 0, false), answer("a", 0, true),
+	}
+	if got := discrimination(answers, rates); got != 1 {
+		t.Errorf("discrimination() = %v, attendu 1", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	q := models.Question{ID: 1, Revision: 1, Options: []string{"A", "B", "C", "D"}, Answer: 0}
+	tests := []struct {
+		name    string
+		chosen  []int // Option choisie par chaque joueur
+		want    []Flag
+		dead    []int
+		summary string
+	}{
+		{
+			name:   "pas assez de réponses pour juger",
+			chosen: []int{0, 0, 0},
+			dead:   []int{1, 2, 3},
+		},
+		{
+			name:    "trop facile",
+			chosen:  []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			want:    []Flag{FlagTooEasy, FlagDeadDistractor},
+			dead:    []int{2, 3}, // B, choisie par 5% des joueurs, n'est pas morte
+			summary: "trop facile • distracteur mort (C, D)",
+		},
+		{
+			name:    "trop difficile",
+			chosen:  []int{1, 1, 1, 2, 2, 2, 3, 3, 3, 1},
+			want:    []Flag{FlagTooHard},
+			summary: "trop difficile",
+		},
+		{
+			name:   "bien calibrée",
+			chosen: []int{0, 0, 0, 0, 0, 1, 1, 2, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var answers []models.RecordedAnswer
+			for i, chosen := range tt.chosen {
+				answers = append(answers, answer(fmt.Sprintf("p%02d", i), chosen, chosen == q.Answer))
+			}
+			stats := Analyze([]models.Question{q}, answers)[0]
+			if stats.Answers != len(tt.chosen) {
+				t.Errorf("%d réponses, attendu %d", stats.Answers, len(tt.chosen))
+			}
+			if !reflect.DeepEqual(stats.Flags, tt.want) {
+				t.Errorf("défauts %v, attendu %v", stats.Flags, tt.want)
+			}
+			if !reflect.DeepEqual(stats.DeadOptions, tt.dead) {
+				t.Errorf("distracteurs morts %v, attendu %v", stats.DeadOptions, tt.dead)
+			}
+			if got := stats.Summary(); got != tt.summary {
+				t.Errorf("Summary() = %q, attendu %q", got, tt.summary)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCurrentRevision(t *testing.T) {
+	q := models.Question{ID: 1, Revision: 2, Options: []string{"A", "B"}, Answer: 0}
+	old := answer("alice", 1, false)
+	current := answer("bob", 0, true)
+	current.Revision = 2
+	stats := Analyze([]models.Question{q}, []models.RecordedAnswer{old, current})[0]
+	if stats.Answers != 1 || stats.SuccessRate != 1 {
+		t.Errorf("%d réponse(s), taux %v, attendu seulement la réponse à la version 2", stats.Answers, stats.SuccessRate)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// announcementsPath liste les annonces programmées, une par ligne:
+// "14:00 texte" chaque jour, "2026-10-20 14:00 texte" une seule fois
+const announcementsPath = "./data/announcements"
+
+// defaultAnnouncementDuration est la durée d'affichage d'une annonce, à défaut
+// d'ANNOUNCEMENT_DURATION (0: jusqu'à ce que le joueur la masque)
+const defaultAnnouncementDuration = 10 * time.Minute
+
+// maxAnnouncements borne le nombre d'annonces affichées ensemble
+const maxAnnouncements = 3
+
+var announcementDuration = defaultAnnouncementDuration
+
+// announcement est un message diffusé à tous les joueurs connectés
+type announcement struct {
+	ID      int64
+	Text    string
+	Expires time.Time // zéro: pas d'expiration
+}
+
+// Active indique si l'annonce est encore à afficher
+func (a announcement) Active(now time.Time) bool {
+	return a.Expires.IsZero() || now.Before(a.Expires)
+}
+
+// announcementMsg transmet une annonce à une session
+type announcementMsg struct {
+	Announcement announcement
+}
+
+// board garde les annonces en cours, montrées aussi aux joueurs qui se
+// connectent après leur diffusion
+type board struct {
+	mu      sync.Mutex
+	lastID  int64
+	current []announcement
+}
+
+var announcementBoard = &board{}
+
+// Publish enregistre une annonce et la diffuse à toutes les sessions ouvertes
+func (b *board) Publish(text string) announcement {
+	b.mu.Lock()
+	b.lastID++
+	a := announcement{ID: b.lastID, Text: text}
+	if announcementDuration > 0 {
+		a.Expires = time.Now().Add(announcementDuration)
+	}
+	b.current = append(activeAnnouncements(b.current, time.Now()), a)
+	b.mu.Unlock()
+
+	sessions.Broadcast(announcementMsg{Announcement: a})
+	return a
+}
+
+// Active retourne les annonces en cours
+func (b *board) Active() []announcement {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current = activeAnnouncements(b.current, time.Now())
+	return append([]announcement(nil), b.current...)
+}
+
+// activeAnnouncements retire les annonces expirées et ne garde que les plus récentes
+func activeAnnouncements(list []announcement, now time.Time) []announcement {
+	var active []announcement
+	for _, a := range list {
+		if a.Active(now) {
+			active = append(active, a)
+		}
+	}
+	return active[max(0, len(active)-maxAnnouncements):]
+}
+
+// announce diffuse l'annonce d'un administrateur, ou programmée si author est vide
+func announce(text, author string) {
+	a := announcementBoard.Publish(text)
+	if author == "" {
+		author = "programmée"
+	}
+	log.Printf("📢 Annonce #%d (%s) diffusée à %d session(s): %s", a.ID, author, sessions.Count(), text)
+}
+
+// scheduledAnnouncement est une annonce du fichier de programmation
+type scheduledAnnouncement struct {
+	date         time.Time // jour de diffusion, zéro pour une annonce quotidienne
+	hour, minute int
+	text         string
+}
+
+// next retourne la prochaine diffusion strictement après after
+func (s scheduledAnnouncement) next(after time.Time) (time.Time, bool) {
+	if !s.date.IsZero() {
+		at := time.Date(s.date.Year(), s.date.Month(), s.date.Day(), s.hour, s.minute, 0, 0, time.Local)
+		return at, at.After(after)
+	}
+	at := time.Date(after.Year(), after.Month(), after.Day(), s.hour, s.minute, 0, 0, time.Local)
+	if !at.After(after) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+// loadAnnouncements lit les annonces programmées; un fichier absent signifie
+// aucune annonce. Les heures sont celles du fuseau du serveur (TZ).
+func loadAnnouncements(path string) ([]scheduledAnnouncement, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled []scheduledAnnouncement
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var s scheduledAnnouncement
+		fields := strings.SplitN(line, " ", 2)
+		if date, err := time.ParseInLocation("2006-01-02", fields[0], time.Local); err == nil && len(fields) == 2 {
+			s.date = date
+			fields = strings.SplitN(strings.TrimSpace(fields[1]), " ", 2)
+		}
+		if len(fields) < 2 || strings.TrimSpace(fields[1]) == "" {
+			return nil, fmt.Errorf("%s ligne %d: heure et texte attendus", path, n)
+		}
+		at, err := time.Parse("15:04", fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s ligne %d: heure invalide: %s", path, n, fields[0])
+		}
+		s.hour, s.minute = at.Hour(), at.Minute()
+		s.text = strings.TrimSpace(fields[1])
+		scheduled = append(scheduled, s)
+	}
+	return scheduled, scanner.Err()
+}
+
+// scheduleAnnouncements diffuse les annonces programmées à leur heure
+func scheduleAnnouncements(scheduled []scheduledAnnouncement) {
+	after := time.Now()
+	for {
+		var next time.Time
+		var due []string
+		for _, s := range scheduled {
+			at, ok := s.next(after)
+			switch {
+			case !ok:
+			case next.IsZero() || at.Before(next):
+				next, due = at, []string{s.text}
+			case at.Equal(next):
+				due = append(due, s.text)
+			}
+		}
+		if next.IsZero() {
+			return
+		}
+
+		time.Sleep(time.Until(next))
+		for _, text := range due {
+			announce(text, "")
+		}
+		after = next
+	}
+}
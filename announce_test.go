@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduledAnnouncementNext(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	daily := scheduledAnnouncement{hour: 9, minute: 30, text: "Café"}
+	dated := scheduledAnnouncement{date: at(15, 0, 0), hour: 18, minute: 0, text: "Maintenance"}
+	tests := []struct {
+		name   string
+		s      scheduledAnnouncement
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"quotidienne, plus tard dans la journée", daily, at(10, 8, 0), at(10, 9, 30), true},
+		{"quotidienne, à l'heure même: le lendemain", daily, at(10, 9, 30), at(11, 9, 30), true},
+		{"quotidienne, heure passée: le lendemain", daily, at(10, 22, 0), at(11, 9, 30), true},
+		{"quotidienne, fin de mois", daily, at(31, 23, 59), time.Date(2024, 4, 1, 9, 30, 0, 0, time.Local), true},
+		{"datée, à venir", dated, at(10, 12, 0), at(15, 18, 0), true},
+		{"datée, le jour même avant l'heure", dated, at(15, 17, 59), at(15, 18, 0), true},
+		{"datée, à l'heure même: déjà diffusée", dated, at(15, 18, 0), at(15, 18, 0), false},
+		{"datée, passée", dated, at(16, 0, 0), at(15, 18, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.s.next(tt.after)
+			if ok != tt.wantOK || (ok && !got.Equal(tt.want)) {
+				t.Errorf("next(%v) = %v, %v, attendu %v, %v", tt.after, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoadAnnouncements(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []scheduledAnnouncement
+		wantErr bool
+	}{
+		{
+			name: "quotidienne et datée, commentaires ignorés",
+			content: "# Annonces\n\n09:30 Café en salle 2\n" +
+				"2024-03-15 18:00   Maintenance à 18h15\n",
+			want: []scheduledAnnouncement{
+				{hour: 9, minute: 30, text: "Café en salle 2"},
+				{date: time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local), hour: 18, text: "Maintenance à 18h15"},
+			},
+		},
+		{name: "heure invalide", content: "25:00 Trop tard\n", wantErr: true},
+		{name: "texte manquant", content: "09:30\n", wantErr: true},
+		{name: "date sans heure", content: "2024-03-15 Maintenance\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "announcements.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := loadAnnouncements(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadAnnouncements: erreur %v, attendu une erreur: %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("%d annonce(s), attendu %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, s := range got {
+				w := tt.want[i]
+				if !s.date.Equal(w.date) || s.hour != w.hour || s.minute != w.minute || s.text != w.text {
+					t.Errorf("annonce %d: %+v, attendu %+v", i+1, s, w)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadAnnouncementsMissingFile(t *testing.T) {
+	got, err := loadAnnouncements(filepath.Join(t.TempDir(), "absent.txt"))
+	if err != nil || got != nil {
+		t.Errorf("fichier absent: %v, %v, attendu aucune annonce sans erreur", got, err)
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"quizz-ssh/models"
+	"quizz-ssh/storage"
+	"strconv"
+)
+
+// maxBodySize borne la taille d'une question envoyée à l'API
+const maxBodySize = 64 << 10
+
+// listQuestions retourne toute la banque, bonnes réponses et questions désactivées comprises
+func (s *Server) listQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := s.store.GetQuestions()
+	if err != nil {
+		serverError(w, "chargement questions", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"questions": nonNil(questions)})
+}
+
+func (s *Server) getQuestion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	q, err := s.store.GetQuestion(id)
+	if errors.Is(err, storage.ErrQuestionNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		serverError(w, "récupération question", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+func (s *Server) createQuestion(w http.ResponseWriter, r *http.Request) {
+	q, ok := readQuestion(w, r)
+	if !ok {
+		return
+	}
+	q.ID = 0
+	s.saveQuestion(w, q, http.StatusCreated)
+}
+
+// updateQuestion enregistre une nouvelle version de la question
+func (s *Server) updateQuestion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	q, ok := readQuestion(w, r)
+	if !ok {
+		return
+	}
+	q.ID = id
+	s.saveQuestion(w, q, http.StatusOK)
+}
+
+func (s *Server) saveQuestion(w http.ResponseWriter, q models.Question, status int) {
+	if err := q.Validate(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	saved, err := s.store.SaveQuestion(q)
+	if errors.Is(err, storage.ErrQuestionNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		serverError(w, "enregistrement question", err)
+		return
+	}
+	log.Printf("🛠️  API: question #%d enregistrée (version %d)", saved.ID, saved.Revision)
+	writeJSON(w, status, saved)
+}
+
+func (s *Server) deleteQuestion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	err := s.store.DeleteQuestion(id)
+	if errors.Is(err, storage.ErrQuestionNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		serverError(w, "suppression question", err)
+		return
+	}
+	log.Printf("🛠️  API: question #%d supprimée", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setDisabled retire une question des quiz ou l'y remet
+func (s *Server) setDisabled(disabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+		err := s.store.SetQuestionDisabled(id, disabled)
+		if errors.Is(err, storage.ErrQuestionNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			serverError(w, "modification question", err)
+			return
+		}
+		if disabled {
+			log.Printf("🛠️  API: question #%d désactivée", id)
+		} else {
+			log.Printf("🛠️  API: question #%d réactivée", id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// listScores retourne les dernières parties d'un joueur, avec leur identifiant
+// pour la modération: ?username=&limit=
+func (s *Server) listScores(w http.ResponseWriter, r *http.Request) {
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		writeError(w, http.StatusBadRequest, "paramètre username obligatoire")
+		return
+	}
+	_, limit, ok := pagination(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "limit invalide")
+		return
+	}
+	scores, err := s.store.GetUserHistory(username, limit)
+	if err != nil {
+		serverError(w, "récupération historique", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"username": username, "scores": nonNil(scores)})
+}
+
+// deleteScore retire une partie des classements (score frauduleux)
+func (s *Server) deleteScore(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	err := s.store.DeleteScore(id)
+	if errors.Is(err, storage.ErrScoreNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		serverError(w, "suppression partie", err)
+		return
+	}
+	log.Printf("🛠️  API: partie #%d supprimée des classements", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pathID lit l'identifiant numérique du chemin; en cas d'erreur la réponse est déjà écrite
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "identifiant invalide")
+		return 0, false
+	}
+	return id, true
+}
+
+// readQuestion décode la question du corps de la requête; en cas d'erreur la
+// réponse est déjà écrite
+func readQuestion(w http.ResponseWriter, r *http.Request) (models.Question, bool) {
+	var q models.Question
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&q); err != nil {
+		writeError(w, http.StatusBadRequest, "question JSON invalide: "+err.Error())
+		return q, false
+	}
+	return q, true
+}
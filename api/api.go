@@ -0,0 +1,337 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"quizz-ssh/analytics"
+	"quizz-ssh/models"
+	"quizz-ssh/storage"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Nombre de lignes retournées par défaut et au maximum par les listes
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// statsTTL est la durée pendant laquelle l'analyse publique des questions est
+// resservie sans être recalculée sur toute la table des réponses
+const statsTTL = time.Minute
+
+// Server expose en JSON les classements, les joueurs et les statistiques des
+// questions, et aux porteurs d'un jeton l'administration de la banque et des scores
+type Server struct {
+	store  storage.Store
+	tokens []string
+	mux    *http.ServeMux
+
+	statsMu sync.Mutex // Un seul calcul à la fois: les requêtes suivantes attendent son résultat
+	stats   []analytics.QuestionStats
+	statsAt time.Time
+}
+
+// New crée l'API sur store; sans jeton, les routes d'administration sont fermées
+func New(store storage.Store, tokens []string) *Server {
+	s := &Server{store: store, tokens: tokens, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /api/categories", s.categories)
+	s.mux.HandleFunc("GET /api/leaderboard", s.leaderboard)
+	s.mux.HandleFunc("GET /api/ratings", s.ratings)
+	s.mux.HandleFunc("GET /api/users/{username}", s.user)
+	s.mux.HandleFunc("GET /api/users/{username}/history", s.history)
+	s.mux.HandleFunc("GET /api/questions/stats", s.questionStats)
+
+	s.mux.HandleFunc("GET /api/admin/questions", s.admin(s.listQuestions))
+	s.mux.HandleFunc("POST /api/admin/questions", s.admin(s.createQuestion))
+	s.mux.HandleFunc("GET /api/admin/questions/{id}", s.admin(s.getQuestion))
+	s.mux.HandleFunc("PUT /api/admin/questions/{id}", s.admin(s.updateQuestion))
+	s.mux.HandleFunc("DELETE /api/admin/questions/{id}", s.admin(s.deleteQuestion))
+	s.mux.HandleFunc("POST /api/admin/questions/{id}/disable", s.admin(s.setDisabled(true)))
+	s.mux.HandleFunc("POST /api/admin/questions/{id}/enable", s.admin(s.setDisabled(false)))
+	s.mux.HandleFunc("GET /api/admin/scores", s.admin(s.listScores))
+	s.mux.HandleFunc("DELETE /api/admin/scores/{id}", s.admin(s.deleteScore))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// authFailures compte les requêtes d'administration refusées
+var authFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "quiz_ssh_auth_failures_total",
+	Help: "Requêtes d'administration de l'API refusées, par motif (missing_token, invalid_token, disabled).",
+}, []string{"reason"})
+
+// admin n'exécute next que pour une requête portant un jeton d'administration
+// (en-tête "Authorization: Bearer <jeton>")
+func (s *Server) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(s.tokens) == 0 {
+			authFailures.WithLabelValues("disabled").Inc()
+			writeError(w, http.StatusForbidden, "administration désactivée: aucun jeton configuré")
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !s.validToken(token) {
+			if ok {
+				authFailures.WithLabelValues("invalid_token").Inc()
+			} else {
+				authFailures.WithLabelValues("missing_token").Inc()
+			}
+			log.Printf("⛔ API: jeton d'administration refusé (%s %s depuis %s)", r.Method, r.URL.Path, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="quiz"`)
+			writeError(w, http.StatusUnauthorized, "jeton d'administration invalide")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) validToken(token string) bool {
+	valid := false
+	for _, t := range s.tokens {
+		// Comparer à tous les jetons, en temps constant
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+func (s *Server) categories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.store.GetCategories()
+	if err != nil {
+		serverError(w, "récupération catégories", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"categories": nonNil(categories)})
+}
+
+// leaderboard retourne une page du classement: ?category=&period=all|day|week|month&search=&offset=&limit=
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	period, ok := models.ParsePeriod(r.URL.Query().Get("period"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "période inconnue (all, day, week, month)")
+		return
+	}
+	offset, limit, ok := pagination(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "offset ou limit invalide")
+		return
+	}
+	category := r.URL.Query().Get("category")
+	scores, total, err := s.store.GetLeaderboardPage(category, period, r.URL.Query().Get("search"), offset, limit)
+	if err != nil {
+		serverError(w, "récupération leaderboard", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"category": orGlobal(category),
+		"period":   period.Name(),
+		"total":    total,
+		"scores":   nonNil(scores),
+	})
+}
+
+// ratings retourne le classement par niveau: ?category=&limit=
+func (s *Server) ratings(w http.ResponseWriter, r *http.Request) {
+	_, limit, ok := pagination(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "limit invalide")
+		return
+	}
+	category := orGlobal(r.URL.Query().Get("category"))
+	ratings, err := s.store.GetRatingLeaderboard(category, limit)
+	if err != nil {
+		serverError(w, "récupération classement par niveau", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"category": category, "ratings": nonNil(ratings)})
+}
+
+// user retourne le profil d'un joueur
+func (s *Server) user(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+	stats, err := s.store.GetUserStats(username)
+	if err != nil {
+		serverError(w, "récupération statistiques joueur", err)
+		return
+	}
+	if stats.Attempts == 0 {
+		writeError(w, http.StatusNotFound, "joueur inconnu")
+		return
+	}
+
+	best, err := s.store.GetUserBestScores(username)
+	if err != nil {
+		serverError(w, "récupération meilleurs scores", err)
+		return
+	}
+	duels, err := s.store.GetDuelRecord(username)
+	if err != nil {
+		serverError(w, "récupération duels", err)
+		return
+	}
+	rating, err := s.store.GetRating(username, "global")
+	if err != nil {
+		serverError(w, "récupération niveau", err)
+		return
+	}
+	streak, err := s.store.GetPlayStreak(username)
+	if err != nil {
+		serverError(w, "récupération série", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"username":     username,
+		"stats":        stats,
+		"success_rate": stats.SuccessRate(),
+		"best":         nonNil(best),
+		"duels":        duels,
+		"rating":       rating,
+		"streak":       streak,
+	})
+}
+
+// history retourne les dernières parties d'un joueur: ?limit=
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	_, limit, ok := pagination(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "limit invalide")
+		return
+	}
+	username := r.PathValue("username")
+	scores, err := s.store.GetUserHistory(username, limit)
+	if err != nil {
+		serverError(w, "récupération historique", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"username": username, "scores": nonNil(scores)})
+}
+
+// questionStat est l'analyse publique d'une question: ni réponses possibles,
+// ni répartition des choix, qui trahiraient la bonne réponse
+type questionStat struct {
+	ID             int              `json:"id"`
+	Revision       int              `json:"revision"`
+	Category       string           `json:"category"`
+	Text           string           `json:"text"`
+	Difficulty     int              `json:"difficulty,omitempty"`
+	Answers        int              `json:"answers"`
+	SuccessRate    float64          `json:"success_rate"`
+	AvgTimeMs      int64            `json:"avg_time_ms"`
+	Discrimination float64          `json:"discrimination"`
+	Judged         bool             `json:"judged"`
+	Flags          []analytics.Flag `json:"flags"`
+}
+
+// questionStats retourne l'analyse des réponses à chaque question; ?flagged=1
+// ne garde que les questions à revoir
+func (s *Server) questionStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.analyze()
+	if err != nil {
+		serverError(w, "analyse des questions", err)
+		return
+	}
+	if flagged, _ := strconv.ParseBool(r.URL.Query().Get("flagged")); flagged {
+		stats = analytics.Flagged(stats)
+	}
+	result := make([]questionStat, 0, len(stats))
+	for _, st := range stats {
+		result = append(result, questionStat{
+			ID:             st.Question.ID,
+			Revision:       st.Question.Revision,
+			Category:       st.Question.Category,
+			Text:           st.Question.Text,
+			Difficulty:     st.Question.Difficulty,
+			Answers:        st.Answers,
+			SuccessRate:    st.SuccessRate,
+			AvgTimeMs:      st.AvgTime.Milliseconds(),
+			Discrimination: st.Discrimination,
+			Judged:         st.Judged(),
+			Flags:          nonNil(st.Flags),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"min_answers": analytics.MinAnswers, "questions": result})
+}
+
+// analyze retourne l'analyse des questions, recalculée au plus une fois par statsTTL
+func (s *Server) analyze() ([]analytics.QuestionStats, error) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	if time.Since(s.statsAt) < statsTTL {
+		return s.stats, nil
+	}
+
+	questions, err := s.store.GetQuestions()
+	if err != nil {
+		return nil, err
+	}
+	answers, err := s.store.GetRecordedAnswers()
+	if err != nil {
+		return nil, err
+	}
+	s.stats = analytics.Analyze(questions, answers)
+	s.statsAt = time.Now()
+	return s.stats, nil
+}
+
+// pagination lit ?offset= et ?limit=, borné à maxLimit
+func pagination(r *http.Request) (offset, limit int, ok bool) {
+	limit = defaultLimit
+	var err error
+	if value := r.URL.Query().Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return 0, 0, false
+		}
+	}
+	return offset, min(limit, maxLimit), true
+}
+
+func orGlobal(category string) string {
+	if category == "" {
+		return "global"
+	}
+	return category
+}
+
+// nonNil sérialise une liste vide en [] plutôt qu'en null
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Erreur écriture réponse API: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// serverError journalise l'erreur et ne renvoie au client qu'un message générique
+func serverError(w http.ResponseWriter, action string, err error) {
+	log.Printf("Erreur API %s: %v", action, err)
+	writeError(w, http.StatusInternalServerError, "erreur interne")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// banner retourne les bandeaux affichés au-dessus de l'écran (maintenance puis
+// annonces), vide s'il n'y en a pas
+func (m *appModel) banner() string {
+	if m.closing != "" {
+		return ""
+	}
+
+	var banners []string
+	if !m.maintenance.IsZero() {
+		remaining := int(math.Ceil(time.Until(m.maintenance).Seconds()))
+		banners = append(banners, m.styles.Banner.Render(m.lang.T("session.maintenance", max(0, remaining))))
+	}
+	for _, a := range m.visibleAnnouncements() {
+		banners = append(banners, m.styles.Announcement.Render(m.lang.T("announce.banner", a.Text)))
+	}
+	return strings.Join(banners, "\n")
+}
+
+// bodyHeight retourne la hauteur disponible pour l'écran sous le bandeau
+func (m *appModel) bodyHeight() int {
+	banner := m.banner()
+	if banner == "" {
+		return m.height
+	}
+	return max(1, m.height-lipgloss.Height(banner))
+}
+
+// visibleAnnouncements retourne les annonces reçues, ni expirées ni masquées
+func (m *appModel) visibleAnnouncements() []announcement {
+	return activeAnnouncements(m.announcements, time.Now())
+}
+
+// receiveAnnouncement ajoute une annonce aux bandeaux de la session
+func (m *appModel) receiveAnnouncement(a announcement) {
+	for _, known := range m.announcements {
+		if known.ID == a.ID {
+			return
+		}
+	}
+	m.announcements = append(m.visibleAnnouncements(), a)
+}
+
+// dismissAnnouncements masque les annonces affichées; ok vaut false s'il n'y en avait pas
+func (m *appModel) dismissAnnouncements() bool {
+	if len(m.visibleAnnouncements()) == 0 {
+		return false
+	}
+	m.announcements = nil
+	return true
+}
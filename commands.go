@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"quizz-ssh/analytics"
+	"quizz-ssh/models"
+	"quizz-ssh/storage"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// runCommand exécute une sous-commande en ligne de commande au lieu de démarrer le serveur
+func runCommand(args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(args[1:])
+	case "questions":
+		return runQuestions(args[1:])
+	case "analyze":
+		return runAnalyze(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return nil
+	default:
+		printUsage()
+		return fmt.Errorf("commande inconnue: %s", args[0])
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage: quiz-server [commande]")
+	fmt.Println()
+	fmt.Println("Sans commande, démarre le serveur SSH.")
+	fmt.Println()
+	fmt.Println("Commandes:")
+	fmt.Println("  migrate status   Affiche l'état des migrations de la base")
+	fmt.Println("  migrate up       Applique les migrations en attente")
+	fmt.Println("  questions import <fichier.json>")
+	fmt.Println("                   Ajoute les questions d'un fichier JSON à la banque")
+	fmt.Println("  analyze          Analyse les réponses et signale les questions à revoir")
+}
+
+// databaseDSN retourne le stockage à utiliser: DATABASE_URL (ex: postgres://...
+// ou "memory"), à défaut DB_PATH, à défaut la base SQLite par défaut
+func databaseDSN() string {
+	return getenv("DATABASE_URL", getenv("DB_PATH", dbPath))
+}
+
+// getenv lit une variable d'environnement avec une valeur par défaut
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getenvDuration lit une durée positive (30s, 15m, 2h...) avec une valeur par défaut
+func getenvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s invalide: %s", key, value)
+	}
+	return d, nil
+}
+
+// getenvInt lit une variable d'environnement entière et positive, avec une valeur par défaut
+func getenvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s invalide: %s", key, value)
+	}
+	return n, nil
+}
+
+func runMigrate(args []string) error {
+	if len(args) != 1 {
+		printUsage()
+		return fmt.Errorf("usage: migrate status|up")
+	}
+
+	d, err := storage.Open(databaseDSN())
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	switch args[0] {
+	case "status":
+		statuses, err := d.MigrationStatus()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNOM\tÉTAT\tAPPLIQUÉE LE")
+		pending := 0
+		for _, s := range statuses {
+			state, appliedAt := "en attente", "-"
+			if s.Applied {
+				state, appliedAt = "appliquée", s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			} else {
+				pending++
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		w.Flush()
+		fmt.Printf("\n%d migration(s) en attente\n", pending)
+		return nil
+
+	case "up":
+		applied, _, err := d.Migrate(nil)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ %d migration(s) appliquée(s)\n", applied)
+		return nil
+
+	default:
+		return fmt.Errorf("sous-commande migrate inconnue: %s (status|up)", args[0])
+	}
+}
+
+func runQuestions(args []string) error {
+	if len(args) != 2 || args[0] != "import" {
+		printUsage()
+		return fmt.Errorf("usage: questions import <fichier.json>")
+	}
+
+	questions, err := storage.LoadQuestions(args[1])
+	if err != nil {
+		return err
+	}
+
+	d, err := storage.NewStore(databaseDSN(), nil)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	imported, err := d.ImportQuestions(questions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %d question(s) importée(s)\n", imported)
+	return nil
+}
+
+func runAnalyze(args []string) error {
+	if len(args) != 0 {
+		printUsage()
+		return fmt.Errorf("usage: analyze")
+	}
+
+	d, err := storage.NewStore(databaseDSN(), nil)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	questions, err := d.GetQuestions()
+	if err != nil {
+		return err
+	}
+	answers, err := d.GetRecordedAnswers()
+	if err != nil {
+		return err
+	}
+	stats := analytics.Analyze(questions, answers)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tVERSION\tCATÉGORIE\tRÉPONSES\tRÉUSSITE\tTEMPS MOYEN\tDISCRIMINATION\tCHOIX (A, B...)\tDÉFAUTS")
+	for _, s := range stats {
+		choices := make([]string, len(s.OptionCounts))
+		for i := range s.OptionCounts {
+			choices[i] = fmt.Sprintf("%.0f%%", s.OptionRate(i)*100)
+		}
+		success, discrimination, avgTime := "-", "-", "-"
+		if s.Answers > 0 {
+			success = fmt.Sprintf("%.0f%%", s.SuccessRate*100)
+			discrimination = fmt.Sprintf("%+.2f", s.Discrimination)
+			avgTime = s.AvgTime.Round(100 * time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%d\tv%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			s.Question.ID, s.Question.Revision, s.Question.Category, s.Answers,
+			success, avgTime, discrimination, strings.Join(choices, " "), s.Summary())
+	}
+	w.Flush()
+
+	flagged := analytics.Flagged(stats)
+	fmt.Printf("\n%d question(s) à revoir (jugées à partir de %d réponses)\n", len(flagged), analytics.MinAnswers)
+	for _, s := range flagged {
+		fmt.Printf("  #%d %s: %s\n", s.Question.ID, models.Truncate(s.Question.Text, 60), s.Summary())
+	}
+	return nil
+}
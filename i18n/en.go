@@ -0,0 +1,259 @@
+package i18n
+
+var en = map[string]string{
+	"lang.name": "English",
+
+	// Formats de date (syntaxe Go)
+	"format.date":     "2006-01-02",
+	"format.datetime": "01/02 15:04",
+
+	// Commun
+	"common.loading":          "Loading...",
+	"common.scroll":           "shift+↑/↓: scroll • lines %d-%d of %d",
+	"common.logged_in":        "Logged in as: %s",
+	"common.help_back_menu":   "q: back to menu",
+	"common.help_enter_menu":  "q or enter: back to menu",
+	"common.help_return_menu": "enter or q: back to menu",
+	"common.help_back":        "esc: back",
+	"common.col_rank":         "Rank",
+	"common.col_player":       "Player",
+	"common.col_score":        "Score",
+	"common.col_success":      "Success",
+	"common.col_time":         "Time",
+	"common.col_category":     "Category",
+	"common.col_date":         "Date",
+
+	// Inactivité et durée de session
+	"session.idle_title":         "⏰ Still there?",
+	"session.idle_warning":       "Without any action, you will be disconnected in %d s.",
+	"session.idle_help":          "Press any key to stay connected",
+	"session.idle_closed":        "⏰ Disconnected after being idle for too long.",
+	"session.max_duration":       "⏰ Maximum session duration reached, see you soon!",
+	"session.maintenance":        "🔧 Maintenance in %d seconds: the server restarts, your current game will be kept",
+	"session.maintenance_closed": "🔧 The server is restarting for maintenance, come back in a few minutes.",
+	"session.reconnect":          "Come back whenever you like!",
+
+	// Annonces
+	"announce.banner": "📢 %s • ctrl+x: hide",
+
+	// Limites de connexion
+	"limits.full":              "🚧 Server full: too many players online right now, try again in a few minutes.",
+	"limits.too_many_ip":       "Too many sessions open from your address: close one before reconnecting.",
+	"limits.too_many_identity": "Too many sessions open with this key: close one before reconnecting.",
+	"limits.banned":            "Access denied.",
+
+	// Pseudo
+	"username.welcome":     "Welcome to the cybersecurity quiz!",
+	"username.prompt":      "Enter your nickname to start",
+	"username.placeholder": "Your nickname...",
+	"username.too_short":   "the nickname must be at least 3 characters long",
+	"username.help":        "enter: confirm • ctrl+c: quit",
+
+	// Menu principal
+	"menu.greeting":    "Hi, %s! 👋",
+	"menu.subtitle":    "Pick an option to continue",
+	"menu.play":        "🎯 Play the Quiz",
+	"menu.leaderboard": "🏆 Leaderboard",
+	"menu.rating":      "📈 Rating leaderboard",
+	"menu.profile":     "👤 My profile",
+	"menu.duel":        "⚔️  Duel",
+	"menu.settings":    "⚙️  Settings",
+	"menu.admin":       "🛠️  Administration",
+	"menu.quit":        "🚪 Quit",
+	"menu.help":        "↑/↓ or j/k: navigate • enter: select • q: quit",
+
+	// Choix de catégorie
+	"category.none": "❌ No category available",
+	"category.help": "↑/↓ or j/k: navigate • enter: select • q: back",
+
+	// Préférences
+	"settings.title":      "⚙️  Settings",
+	"settings.language":   "Language",
+	"settings.theme":      "Theme",
+	"theme.dark":          "Dark",
+	"theme.light":         "Light",
+	"theme.high-contrast": "High contrast",
+	"theme.monochrome":    "Monochrome",
+	"settings.confirm":    "Confirm answers",
+	"settings.mouse":      "Mouse",
+	"settings.on":         "on",
+	"settings.off":        "off",
+	"settings.help":       "↑/↓: setting • ←/→: change • enter: save • esc: cancel",
+
+	// Quiz
+	"quiz.no_questions":       "❌ No question available",
+	"quiz.ready_title":        "🎮 Ready to start? 🎮",
+	"quiz.ready_info":         "Category: %s\nNumber of questions: %d",
+	"quiz.ready_resume":       "Resuming at question %d (score: %d)",
+	"quiz.ready_start":        "⚡ Press any key to start the quiz ⚡",
+	"quiz.progress":           "Question %d/%d",
+	"quiz.score":              "Score: %d/%d",
+	"quiz.correct":            "🎉 Correct!",
+	"quiz.wrong":              "❌ Wrong answer!",
+	"quiz.mark_correct":       "correct answer",
+	"quiz.mark_chosen":        "your answer",
+	"quiz.help_answer":        "↑/↓ or j/k: navigate • a-%c or 1-%d: answer • enter: confirm • q: quit",
+	"quiz.confirm":            "Submit answer %c?",
+	"quiz.help_confirm":       "enter or same key: confirm • ↑/↓ or esc: change • q: quit",
+	"quiz.help_next":          "enter: next question • q: quit",
+	"quiz.help_next_report":   "enter: next question • s: report the question • q: quit",
+	"quiz.report_placeholder": "Wrong answer, ambiguous question...",
+	"quiz.report_help":        "enter: send the report • esc: cancel",
+	"quiz.reported":           "🚩 Thanks, the question has been reported",
+	"quiz.finished":           "🎊 Quiz Complete! 🎊",
+	"quiz.final_score":        "Final Score: %d/%d (%.1f%%)",
+	"quiz.category":           "Category: %s",
+	"quiz.badge_unlocked":     "%s Badge unlocked: %s — %s",
+	"quiz.perfect":            "🏆 Perfect! You're an expert!",
+	"quiz.excellent":          "🌟 Excellent work!",
+	"quiz.not_bad":            "👍 Not bad, keep it up!",
+	"quiz.keep_training":      "💪 Keep practicing!",
+
+	// Reprise d'une partie interrompue
+	"resume.title":   "⏸️  Interrupted game",
+	"resume.info":    "Category: %s • last answer on %s\nQuestions answered: %d of %d • score: %d",
+	"resume.warning": "If you don't resume, the game will be recorded as abandoned",
+	"resume.help":    "y or enter: resume • n or esc: abandon",
+
+	// Duel
+	"duel.you":             "You",
+	"duel.opponent_score":  "%d/%d • score %d",
+	"duel.waiting_result":  "⏳ Waiting for %s... (%d/%d)",
+	"duel.help_waiting":    "enter: back to menu (the result will be saved)",
+	"duel.victory":         "🏆 Victory!",
+	"duel.victory_forfeit": "🏆 Victory by forfeit!",
+	"duel.draw":            "🤝 Perfect tie!",
+	"duel.defeat":          "💀 Defeat, %s wins",
+	"duel.lobby_title":     "⚔️  Duel - Pick your opponent",
+	"duel.record":          "Record: %d win(s) • %d loss(es) • %d draw(s)",
+	"duel.no_players":      "❌ No player available right now",
+	"duel.auto_refresh":    "The list refreshes automatically",
+	"duel.lobby_help":      "↑/↓ or j/k: navigate • enter: challenge • q: back",
+	"duel.invite_title":    "⚔️  %s challenges you to a duel!",
+	"duel.invite_rules":    "Same questions, the best score wins",
+	"duel.invite_help":     "y or enter: accept • n or esc: decline",
+	"duel.sent":            "⏳ Challenge sent",
+	"duel.awaiting_answer": "Waiting for %s to answer...",
+	"duel.help_cancel":     "esc: cancel the challenge",
+
+	// Leaderboard
+	"leaderboard.search_placeholder": "nickname...",
+	"leaderboard.title_global":       "🏆 Global Leaderboard",
+	"leaderboard.title_category":     "📊 Leaderboard - %s",
+	"leaderboard.error":              "❌ Could not load the leaderboard",
+	"leaderboard.no_match":           "❌ No nickname matches the search",
+	"leaderboard.empty":              "❌ No score recorded yet",
+	"leaderboard.be_first":           "Be the first to play! 🎮",
+	"leaderboard.stats":              "Total games: %d | Unique players: %d",
+	"leaderboard.unranked":           "You are not ranked for this period yet",
+	"leaderboard.page":               "Page %d/%d • %d player(s)",
+	"leaderboard.help_search":        "enter: confirm the search • esc: clear",
+	"leaderboard.help":               "←/→: period • PgUp/PgDn: page • /: search a nickname • q or enter: back to menu",
+	"period.day":                     "Today",
+	"period.week":                    "This week",
+	"period.month":                   "This month",
+	"period.all":                     "All time",
+
+	// Page web des classements
+	"web.title":   "Leaderboards • Cybersec Quiz",
+	"web.global":  "Global",
+	"web.live":    "Live",
+	"web.updated": "Updated at %s",
+
+	// Classement par niveau
+	"rating.title":          "📈 Rating leaderboard",
+	"rating.subtitle":       "Logged in as: %s • Your rating: %.0f",
+	"rating.empty":          "❌ No rating computed yet",
+	"rating.empty_hint":     "Play a game to get your rating! 🎮",
+	"rating.col_rating":     "Rating",
+	"rating.col_games":      "Games",
+	"rating.history":        "Your history",
+	"rating.title_category": "📈 Rating leaderboard - %s",
+	"rating.tab_global":     "All categories",
+	"rating.error":          "❌ Could not load the rating leaderboard",
+	"rating.help":           "←/→: category • q or enter: back to menu",
+
+	// Profil
+	"profile.title":      "👤 %s's profile",
+	"profile.error":      "❌ Could not load the profile",
+	"profile.empty":      "❌ No game played yet",
+	"profile.empty_hint": "Start a quiz to track your progress! 🎮",
+	"profile.summary":    "Games: %d • Average success: %.1f%% • Play time: %s\nRating: %.0f • Duels: %dW / %dL / %dD",
+	"profile.sparkline":  "Your last %d games:",
+	"profile.badges":     "Badges (%d/%d)",
+	"profile.unlocked":   "(on %s)",
+	"profile.col_best":   "Best",
+
+	// Badges
+	"achievement.first_perfect.name":            "Flawless",
+	"achievement.first_perfect.description":     "Score 100% in a quiz",
+	"achievement.streak_10.name":                "Dedicated",
+	"achievement.streak_10.description":         "Play 10 days in a row",
+	"achievement.all_categories_80.name":        "All-rounder",
+	"achievement.all_categories_80.description": "Score over 80% in every category",
+	"achievement.questions_500.name":            "Encyclopedia",
+	"achievement.questions_500.description":     "Answer 500 questions",
+
+	// Administration
+	"admin.title":              "🛠️  Question administration",
+	"admin.count":              "%d question(s) in the bank",
+	"admin.pending_reports":    "🚩 %d pending report(s) • r: moderation queue",
+	"admin.load_error":         "❌ Could not load the questions",
+	"admin.categories_help":    "↑/↓: navigate • enter: open • n: new question • r: reports • a: analytics • m: announcement • q: back to menu",
+	"admin.category_count":     "%d question(s)",
+	"admin.col_revision":       "Ver.",
+	"admin.col_difficulty":     "Diff.",
+	"admin.col_text":           "Question",
+	"admin.questions_help":     "enter/e: edit • p: preview • x: enable/disable • d: delete • n: new question • esc: categories",
+	"admin.preview":            "👁️  Preview",
+	"admin.preview_help":       "tab: before/after answering • esc: back",
+	"admin.delete_title":       "🗑️  Delete the question?",
+	"admin.delete_note":        "Games already played keep their history.",
+	"admin.delete_help":        "y: delete • n or esc: cancel",
+	"admin.new_question":       "➕ New question",
+	"admin.edit_question":      "✏️  Question #%d",
+	"admin.form_help":          "tab/↑/↓: field • ctrl+p: preview • ctrl+s: save • esc: cancel",
+	"admin.field_category":     "Category",
+	"admin.field_text":         "Question",
+	"admin.field_option":       "Answer %d",
+	"admin.field_answer":       "Correct answer",
+	"admin.field_explanation":  "Explanation",
+	"admin.field_difficulty":   "Difficulty",
+	"admin.difficulty_hint":    "0 (unset) to 5",
+	"admin.answer_required":    "enter the number of the correct answer",
+	"admin.difficulty_invalid": "the difficulty must be a number between 0 and 5",
+	"admin.saved":              "✅ Question #%d saved (revision %d)",
+	"admin.deleted":            "🗑️  Question #%d deleted",
+	"admin.delete_failed":      "❌ Could not delete: %v",
+	"admin.disabled":           "⛔ Question #%d disabled",
+	"admin.enabled":            "✅ Question #%d enabled",
+	"admin.update_failed":      "❌ Could not update: %v",
+	"admin.resolve_failed":     "❌ Reports not closed: %v",
+	"admin.resolved":           "✅ %d report(s) on question #%d closed",
+	"admin.close_failed":       "❌ Could not close: %v",
+	"admin.reports_title":      "🚩 Moderation queue",
+	"admin.reports_count":      "%d report(s) on %d question(s)",
+	"admin.reports_empty":      "✅ No pending report",
+	"admin.reports_help":       "enter/e: fix • p: preview • x: disable • r: dismiss • esc: back",
+	"admin.deleted_question":   "(deleted question)",
+	"admin.analytics_title":    "📈 Question analytics",
+	"admin.analytics_count":    "%d question(s) to review out of %d • judged from %d answers",
+	"admin.analytics_failed":   "❌ Analysis failed: %v",
+	"admin.analytics_clean":    "✅ No question to review",
+	"admin.analytics_all_help": "f: all questions • esc: back",
+	"admin.analytics_help":     "↑/↓: navigate • enter/e: edit • f: questions to review/all • esc: back",
+	"admin.col_answers":        "Ans.",
+	"admin.col_discrimination": "Discr.",
+	"admin.col_flags":          "Issues",
+	"admin.flag_too_easy":      "too easy",
+	"admin.flag_too_hard":      "too hard",
+	"admin.flag_dead":          "dead distractor",
+
+	// Administration: annonces aux joueurs
+	"admin.announce_title":       "📢 Announcement to players",
+	"admin.announce_note":        "Shown as a banner on the screen of every connected player",
+	"admin.announce_placeholder": "e.g. tournament at 2pm, meet in the Duel menu!",
+	"admin.announce_help":        "enter: broadcast • esc: cancel",
+	"admin.announce_empty":       "❌ The announcement is empty",
+	"admin.announce_sent":        "📢 Announcement broadcast",
+}
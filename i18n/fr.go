@@ -0,0 +1,260 @@
+package i18n
+
+// fr est le catalogue de référence: toute clé utilisée par l'interface doit y figurer
+var fr = map[string]string{
+	"lang.name": "Français",
+
+	// Formats de date (syntaxe Go)
+	"format.date":     "02/01/2006",
+	"format.datetime": "02/01 15:04",
+
+	// Commun
+	"common.loading":          "Chargement...",
+	"common.scroll":           "shift+↑/↓: défiler • lignes %d-%d sur %d",
+	"common.logged_in":        "Connecté en tant que: %s",
+	"common.help_back_menu":   "q: retour au menu",
+	"common.help_enter_menu":  "q ou enter: retour au menu",
+	"common.help_return_menu": "enter ou q: retour au menu",
+	"common.help_back":        "esc: retour",
+	"common.col_rank":         "Rank",
+	"common.col_player":       "Pseudo",
+	"common.col_score":        "Score",
+	"common.col_success":      "Réussite",
+	"common.col_time":         "Temps",
+	"common.col_category":     "Catégorie",
+	"common.col_date":         "Date",
+
+	// Inactivité et durée de session
+	"session.idle_title":         "⏰ Toujours là ?",
+	"session.idle_warning":       "Sans action de ta part, tu seras déconnecté dans %d s.",
+	"session.idle_help":          "Appuie sur n'importe quelle touche pour rester connecté",
+	"session.idle_closed":        "⏰ Déconnecté après une trop longue inactivité.",
+	"session.max_duration":       "⏰ Durée maximale de session atteinte, à bientôt !",
+	"session.maintenance":        "🔧 Maintenance dans %d secondes: le serveur redémarre, ta partie en cours sera conservée",
+	"session.maintenance_closed": "🔧 Le serveur redémarre pour maintenance, reviens dans quelques minutes.",
+	"session.reconnect":          "Reconnecte-toi quand tu veux !",
+
+	// Annonces
+	"announce.banner": "📢 %s • ctrl+x: masquer",
+
+	// Limites de connexion
+	"limits.full":              "🚧 Serveur plein: trop de joueurs connectés pour l'instant, réessaie dans quelques minutes.",
+	"limits.too_many_ip":       "Trop de sessions ouvertes depuis ton adresse: ferme-en une avant de te reconnecter.",
+	"limits.too_many_identity": "Trop de sessions ouvertes avec cette clé: ferme-en une avant de te reconnecter.",
+	"limits.banned":            "Accès refusé.",
+
+	// Pseudo
+	"username.welcome":     "Bienvenue sur le quiz de cybersécurité !",
+	"username.prompt":      "Entre ton pseudo pour commencer",
+	"username.placeholder": "Ton pseudo...",
+	"username.too_short":   "le pseudo doit faire au moins 3 caractères",
+	"username.help":        "enter: valider • ctrl+c: quitter",
+
+	// Menu principal
+	"menu.greeting":    "Salut, %s ! 👋",
+	"menu.subtitle":    "Choisis une option pour continuer",
+	"menu.play":        "🎯 Jouer au Quiz",
+	"menu.leaderboard": "🏆 Leaderboard",
+	"menu.rating":      "📈 Classement par niveau",
+	"menu.profile":     "👤 Mon profil",
+	"menu.duel":        "⚔️  Duel",
+	"menu.settings":    "⚙️  Préférences",
+	"menu.admin":       "🛠️  Administration",
+	"menu.quit":        "🚪 Quitter",
+	"menu.help":        "↑/↓ ou j/k: naviguer • enter: sélectionner • q: quitter",
+
+	// Choix de catégorie
+	"category.none": "❌ Aucune catégorie disponible",
+	"category.help": "↑/↓ ou j/k: naviguer • enter: sélectionner • q: retour",
+
+	// Préférences
+	"settings.title":      "⚙️  Préférences",
+	"settings.language":   "Langue",
+	"settings.theme":      "Thème",
+	"theme.dark":          "Sombre",
+	"theme.light":         "Clair",
+	"theme.high-contrast": "Contraste élevé",
+	"theme.monochrome":    "Monochrome",
+	"settings.confirm":    "Confirmation",
+	"settings.mouse":      "Souris",
+	"settings.on":         "activé",
+	"settings.off":        "désactivé",
+	"settings.help":       "↑/↓: réglage • ←/→: changer • enter: enregistrer • esc: annuler",
+
+	// Quiz
+	"quiz.no_questions":       "❌ Aucune question disponible",
+	"quiz.ready_title":        "🎮 Prêt à commencer ? 🎮",
+	"quiz.ready_info":         "Catégorie: %s\nNombre de questions: %d",
+	"quiz.ready_resume":       "Reprise à la question %d (score: %d)",
+	"quiz.ready_start":        "⚡ Appuyez sur n'importe quelle touche pour commencer le quiz ⚡",
+	"quiz.progress":           "Question %d/%d",
+	"quiz.score":              "Score: %d/%d",
+	"quiz.correct":            "🎉 Bonne réponse !",
+	"quiz.wrong":              "❌ Mauvaise réponse !",
+	"quiz.mark_correct":       "bonne réponse",
+	"quiz.mark_chosen":        "ta réponse",
+	"quiz.help_answer":        "↑/↓ ou j/k: naviguer • a-%c ou 1-%d: répondre • enter: valider • q: quitter",
+	"quiz.confirm":            "Valider la réponse %c ?",
+	"quiz.help_confirm":       "enter ou même touche: confirmer • ↑/↓ ou esc: changer • q: quitter",
+	"quiz.help_next":          "enter: question suivante • q: quitter",
+	"quiz.help_next_report":   "enter: question suivante • s: signaler la question • q: quitter",
+	"quiz.report_placeholder": "Réponse fausse, question ambiguë...",
+	"quiz.report_help":        "enter: envoyer le signalement • esc: annuler",
+	"quiz.reported":           "🚩 Merci, la question a été signalée",
+	"quiz.finished":           "🎊 Quiz Terminé ! 🎊",
+	"quiz.final_score":        "Score Final: %d/%d (%.1f%%)",
+	"quiz.category":           "Catégorie: %s",
+	"quiz.badge_unlocked":     "%s Badge débloqué : %s — %s",
+	"quiz.perfect":            "🏆 Parfait ! Tu es un(e) expert(e) !",
+	"quiz.excellent":          "🌟 Excellent travail !",
+	"quiz.not_bad":            "👍 Pas mal, continue comme ça !",
+	"quiz.keep_training":      "💪 Continue à t'entraîner !",
+
+	// Reprise d'une partie interrompue
+	"resume.title":   "⏸️  Partie interrompue",
+	"resume.info":    "Catégorie: %s • dernière réponse le %s\nQuestions répondues: %d sur %d • score: %d",
+	"resume.warning": "Sans reprise, la partie sera enregistrée comme abandonnée",
+	"resume.help":    "o/y ou enter: reprendre • n ou esc: abandonner",
+
+	// Duel
+	"duel.you":             "Toi",
+	"duel.opponent_score":  "%d/%d • score %d",
+	"duel.waiting_result":  "⏳ En attente de %s... (%d/%d)",
+	"duel.help_waiting":    "enter: retour au menu (le résultat sera enregistré)",
+	"duel.victory":         "🏆 Victoire !",
+	"duel.victory_forfeit": "🏆 Victoire par forfait !",
+	"duel.draw":            "🤝 Égalité parfaite !",
+	"duel.defeat":          "💀 Défaite, %s l'emporte",
+	"duel.lobby_title":     "⚔️  Duel - Choisis ton adversaire",
+	"duel.record":          "Bilan: %d victoire(s) • %d défaite(s) • %d égalité(s)",
+	"duel.no_players":      "❌ Aucun joueur disponible pour l'instant",
+	"duel.auto_refresh":    "La liste se met à jour automatiquement",
+	"duel.lobby_help":      "↑/↓ ou j/k: naviguer • enter: défier • q: retour",
+	"duel.invite_title":    "⚔️  %s te défie en duel !",
+	"duel.invite_rules":    "Mêmes questions, le meilleur score l'emporte",
+	"duel.invite_help":     "o/y ou enter: accepter • n ou esc: refuser",
+	"duel.sent":            "⏳ Défi envoyé",
+	"duel.awaiting_answer": "En attente de la réponse de %s...",
+	"duel.help_cancel":     "esc: annuler le défi",
+
+	// Leaderboard
+	"leaderboard.search_placeholder": "pseudo...",
+	"leaderboard.title_global":       "🏆 Leaderboard Global",
+	"leaderboard.title_category":     "📊 Leaderboard - %s",
+	"leaderboard.error":              "❌ Impossible de charger le classement",
+	"leaderboard.no_match":           "❌ Aucun pseudo ne correspond à la recherche",
+	"leaderboard.empty":              "❌ Aucun score enregistré pour l'instant",
+	"leaderboard.be_first":           "Sois le premier à jouer ! 🎮",
+	"leaderboard.stats":              "Total parties: %d | Joueurs uniques: %d",
+	"leaderboard.unranked":           "Tu n'es pas encore classé sur cette période",
+	"leaderboard.page":               "Page %d/%d • %d joueur(s)",
+	"leaderboard.help_search":        "enter: valider la recherche • esc: effacer",
+	"leaderboard.help":               "←/→: période • PgUp/PgDn: page • /: chercher un pseudo • q ou enter: retour au menu",
+	"period.day":                     "Aujourd'hui",
+	"period.week":                    "Cette semaine",
+	"period.month":                   "Ce mois",
+	"period.all":                     "Depuis toujours",
+
+	// Page web des classements
+	"web.title":   "Classements • Cybersec Quiz",
+	"web.global":  "Global",
+	"web.live":    "En direct",
+	"web.updated": "Mis à jour à %s",
+
+	// Classement par niveau
+	"rating.title":          "📈 Classement par niveau",
+	"rating.subtitle":       "Connecté en tant que: %s • Ton niveau: %.0f",
+	"rating.empty":          "❌ Aucun niveau calculé pour l'instant",
+	"rating.empty_hint":     "Joue une partie pour obtenir ton niveau ! 🎮",
+	"rating.col_rating":     "Niveau",
+	"rating.col_games":      "Parties",
+	"rating.history":        "Ton historique",
+	"rating.title_category": "📈 Classement par niveau - %s",
+	"rating.tab_global":     "Toutes catégories",
+	"rating.error":          "❌ Impossible de charger le classement par niveau",
+	"rating.help":           "←/→: catégorie • q ou enter: retour au menu",
+
+	// Profil
+	"profile.title":      "👤 Profil de %s",
+	"profile.error":      "❌ Impossible de charger le profil",
+	"profile.empty":      "❌ Aucune partie jouée pour l'instant",
+	"profile.empty_hint": "Lance un quiz pour suivre ta progression ! 🎮",
+	"profile.summary":    "Parties: %d • Réussite moyenne: %.1f%% • Temps de jeu: %s\nNiveau: %.0f • Duels: %dV / %dD / %dN",
+	"profile.sparkline":  "Tes %d dernières parties:",
+	"profile.badges":     "Badges (%d/%d)",
+	"profile.unlocked":   "(le %s)",
+	"profile.col_best":   "Meilleur",
+
+	// Badges
+	"achievement.first_perfect.name":            "Sans faute",
+	"achievement.first_perfect.description":     "Obtenir 100% à un quiz",
+	"achievement.streak_10.name":                "Assidu",
+	"achievement.streak_10.description":         "Jouer 10 jours d'affilée",
+	"achievement.all_categories_80.name":        "Polyvalent",
+	"achievement.all_categories_80.description": "Dépasser 80% dans toutes les catégories",
+	"achievement.questions_500.name":            "Encyclopédie",
+	"achievement.questions_500.description":     "Répondre à 500 questions",
+
+	// Administration
+	"admin.title":              "🛠️  Administration des questions",
+	"admin.count":              "%d question(s) dans la banque",
+	"admin.pending_reports":    "🚩 %d signalement(s) en attente • r: file de modération",
+	"admin.load_error":         "❌ Impossible de charger les questions",
+	"admin.categories_help":    "↑/↓: naviguer • enter: ouvrir • n: nouvelle question • r: signalements • a: analyse • m: annonce • q: retour au menu",
+	"admin.category_count":     "%d question(s)",
+	"admin.col_revision":       "Ver.",
+	"admin.col_difficulty":     "Diff.",
+	"admin.col_text":           "Énoncé",
+	"admin.questions_help":     "enter/e: modifier • p: aperçu • x: activer/désactiver • d: supprimer • n: nouvelle question • esc: catégories",
+	"admin.preview":            "👁️  Aperçu",
+	"admin.preview_help":       "tab: avant/après réponse • esc: retour",
+	"admin.delete_title":       "🗑️  Supprimer la question ?",
+	"admin.delete_note":        "Les parties déjà jouées gardent leur historique.",
+	"admin.delete_help":        "y: supprimer • n ou esc: annuler",
+	"admin.new_question":       "➕ Nouvelle question",
+	"admin.edit_question":      "✏️  Question #%d",
+	"admin.form_help":          "tab/↑/↓: champ • ctrl+p: aperçu • ctrl+s: enregistrer • esc: annuler",
+	"admin.field_category":     "Catégorie",
+	"admin.field_text":         "Énoncé",
+	"admin.field_option":       "Réponse %d",
+	"admin.field_answer":       "Bonne réponse",
+	"admin.field_explanation":  "Explication",
+	"admin.field_difficulty":   "Difficulté",
+	"admin.difficulty_hint":    "0 (non renseignée) à 5",
+	"admin.answer_required":    "indique le numéro de la bonne réponse",
+	"admin.difficulty_invalid": "la difficulté doit être un nombre entre 0 et 5",
+	"admin.saved":              "✅ Question #%d enregistrée (version %d)",
+	"admin.deleted":            "🗑️  Question #%d supprimée",
+	"admin.delete_failed":      "❌ Suppression impossible: %v",
+	"admin.disabled":           "⛔ Question #%d désactivée",
+	"admin.enabled":            "✅ Question #%d réactivée",
+	"admin.update_failed":      "❌ Modification impossible: %v",
+	"admin.resolve_failed":     "❌ Signalements non clôturés: %v",
+	"admin.resolved":           "✅ %d signalement(s) de la question #%d clôturé(s)",
+	"admin.close_failed":       "❌ Clôture impossible: %v",
+	"admin.reports_title":      "🚩 File de modération",
+	"admin.reports_count":      "%d signalement(s) sur %d question(s)",
+	"admin.reports_empty":      "✅ Aucun signalement en attente",
+	"admin.reports_help":       "enter/e: corriger • p: aperçu • x: désactiver • r: classer sans suite • esc: retour",
+	"admin.deleted_question":   "(question supprimée)",
+	"admin.analytics_title":    "📈 Analyse des questions",
+	"admin.analytics_count":    "%d question(s) à revoir sur %d • jugées à partir de %d réponses",
+	"admin.analytics_failed":   "❌ Analyse impossible: %v",
+	"admin.analytics_clean":    "✅ Aucune question à revoir",
+	"admin.analytics_all_help": "f: toutes les questions • esc: retour",
+	"admin.analytics_help":     "↑/↓: naviguer • enter/e: modifier • f: questions à revoir/toutes • esc: retour",
+	"admin.col_answers":        "Rép.",
+	"admin.col_discrimination": "Discr.",
+	"admin.col_flags":          "Défauts",
+	"admin.flag_too_easy":      "trop facile",
+	"admin.flag_too_hard":      "trop difficile",
+	"admin.flag_dead":          "distracteur mort",
+
+	// Administration: annonces aux joueurs
+	"admin.announce_title":       "📢 Annonce aux joueurs",
+	"admin.announce_note":        "Affichée en bandeau sur l'écran de tous les joueurs connectés",
+	"admin.announce_placeholder": "ex: tournoi à 14h, rendez-vous dans le menu Duel !",
+	"admin.announce_help":        "enter: diffuser • esc: annuler",
+	"admin.announce_empty":       "❌ L'annonce est vide",
+	"admin.announce_sent":        "📢 Annonce diffusée",
+}
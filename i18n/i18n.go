@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang est le code d'une langue de l'interface ("fr", "en")
+type Lang string
+
+const (
+	French  Lang = "fr"
+	English Lang = "en"
+)
+
+// Supported liste les langues proposées aux joueurs, dans l'ordre d'affichage
+var Supported = []Lang{French, English}
+
+// Default est la langue utilisée quand un message ou une traduction manque.
+// Elle peut être changée au démarrage (DEFAULT_LANG).
+var Default = French
+
+// catalogs associe à chaque langue ses messages, indexés par clé ("menu.play")
+var catalogs = map[Lang]map[string]string{
+	French:  fr,
+	English: en,
+}
+
+// Parse reconnaît une langue supportée, y compris sous forme de locale ("en_US.UTF-8")
+func Parse(s string) (Lang, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "_-."); i >= 0 {
+		s = s[:i]
+	}
+	for _, l := range Supported {
+		if string(l) == s {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// Name retourne le nom de la langue dans cette langue
+func (l Lang) Name() string {
+	return l.T("lang.name")
+}
+
+// T traduit le message key, formaté avec args s'il y en a.
+// Un message absent est cherché dans la langue par défaut, sinon la clé est affichée.
+func (l Lang) T(key string, args ...any) string {
+	msg, ok := catalogs[l][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
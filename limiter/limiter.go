@@ -0,0 +1,184 @@
+package limiter
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Reason explique le refus d'une connexion ou d'une session
+type Reason string
+
+const (
+	Banned          Reason = "banni"
+	RateLimited     Reason = "débit"
+	TooManyIP       Reason = "sessions par IP"
+	TooManyIdentity Reason = "sessions par identité"
+	Full            Reason = "serveur plein"
+)
+
+// Config fixe les limites; une valeur nulle désactive la limite correspondante
+type Config struct {
+	RatePerMinute  int // Nouvelles connexions par IP sur une minute glissante
+	MaxPerIP       int // Sessions simultanées par IP
+	MaxPerIdentity int // Sessions simultanées par identité (empreinte de clé)
+	MaxSessions    int // Sessions simultanées sur tout le serveur
+
+	BannedNets       []*net.IPNet
+	BannedIdentities []string
+}
+
+// Stats regroupe les compteurs depuis le démarrage
+type Stats struct {
+	Sessions int // Sessions ouvertes
+	Accepted int // Sessions acceptées
+	Rejected map[Reason]int
+}
+
+// Limiter applique les limites de connexions et de sessions; il est sûr pour
+// un usage concurrent
+type Limiter struct {
+	mu          sync.Mutex
+	cfg         Config
+	now         func() time.Time // Horloge, remplacée dans les tests
+	banned      map[string]bool
+	attempts    map[string][]time.Time // connexions de la dernière minute, par IP
+	perIP       map[string]int
+	perIdentity map[string]int
+	sessions    int
+	accepted    int
+	rejected    map[Reason]int
+}
+
+// maxTracked borne le nombre d'IP suivies avant une purge des entrées expirées
+const maxTracked = 1024
+
+func New(cfg Config) *Limiter {
+	banned := make(map[string]bool, len(cfg.BannedIdentities))
+	for _, id := range cfg.BannedIdentities {
+		banned[id] = true
+	}
+	return &Limiter{
+		cfg:         cfg,
+		now:         time.Now,
+		banned:      banned,
+		attempts:    make(map[string][]time.Time),
+		perIP:       make(map[string]int),
+		perIdentity: make(map[string]int),
+		rejected:    make(map[Reason]int),
+	}
+}
+
+// AllowConn vérifie une nouvelle connexion avant la négociation SSH:
+// adresse bannie ou trop de connexions récentes depuis cette adresse
+func (l *Limiter) AllowConn(ip net.IP) (Reason, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, n := range l.cfg.BannedNets {
+		if n.Contains(ip) {
+			l.rejected[Banned]++
+			return Banned, false
+		}
+	}
+
+	if l.cfg.RatePerMinute <= 0 {
+		return "", true
+	}
+	now := l.now()
+	if len(l.attempts) > maxTracked {
+		l.prune(now)
+	}
+	key := ip.String()
+	recent := keepSince(l.attempts[key], now.Add(-time.Minute))
+	if len(recent) >= l.cfg.RatePerMinute {
+		l.attempts[key] = recent
+		l.rejected[RateLimited]++
+		return RateLimited, false
+	}
+	l.attempts[key] = append(recent, now)
+	return "", true
+}
+
+// Acquire réserve une place pour une session. exempt (administrateurs) ne
+// lève que la limite globale, pour pouvoir intervenir sur un serveur plein.
+// release libère la place à la fin de la session.
+func (l *Limiter) Acquire(ip net.IP, identity string, exempt bool) (release func(), reason Reason, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := ip.String()
+	switch {
+	case identity != "" && l.banned[identity]:
+		reason = Banned
+	case l.cfg.MaxSessions > 0 && l.sessions >= l.cfg.MaxSessions && !exempt:
+		reason = Full
+	case l.cfg.MaxPerIP > 0 && l.perIP[key] >= l.cfg.MaxPerIP:
+		reason = TooManyIP
+	case identity != "" && l.cfg.MaxPerIdentity > 0 && l.perIdentity[identity] >= l.cfg.MaxPerIdentity:
+		reason = TooManyIdentity
+	}
+	if reason != "" {
+		l.rejected[reason]++
+		return nil, reason, false
+	}
+
+	l.sessions++
+	l.accepted++
+	l.perIP[key]++
+	if identity != "" {
+		l.perIdentity[identity]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.release(key, identity) })
+	}, "", true
+}
+
+func (l *Limiter) release(key, identity string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sessions--
+	if l.perIP[key]--; l.perIP[key] <= 0 {
+		delete(l.perIP, key)
+	}
+	if identity != "" {
+		if l.perIdentity[identity]--; l.perIdentity[identity] <= 0 {
+			delete(l.perIdentity, identity)
+		}
+	}
+}
+
+// Stats retourne une copie des compteurs
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rejected := make(map[Reason]int, len(l.rejected))
+	for r, n := range l.rejected {
+		rejected[r] = n
+	}
+	return Stats{Sessions: l.sessions, Accepted: l.accepted, Rejected: rejected}
+}
+
+// prune oublie les adresses sans connexion dans la dernière minute
+func (l *Limiter) prune(now time.Time) {
+	for key, times := range l.attempts {
+		if recent := keepSince(times, now.Add(-time.Minute)); len(recent) > 0 {
+			l.attempts[key] = recent
+		} else {
+			delete(l.attempts, key)
+		}
+	}
+}
+
+// keepSince retire les instants antérieurs à since (la liste est triée)
+func keepSince(times []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(times) && times[i].Before(since) {
+		i++
+	}
+	return times[i:]
+}
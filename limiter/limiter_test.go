@@ -0,0 +1,211 @@
+package limiter
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// clock est une horloge avancée à la main
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestLimiter(cfg Config) (*Limiter, *clock) {
+	c := &clock{t: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	l := New(cfg)
+	l.now = c.now
+	return l, c
+}
+
+func TestAllowConnRate(t *testing.T) {
+	ip := net.ParseIP("192.0.2.1")
+	other := net.ParseIP("192.0.2.2")
+	type step struct {
+		after time.Duration // Avance de l'horloge avant la connexion
+		ip    net.IP
+		want  bool
+	}
+	tests := []struct {
+		name  string
+		rate  int
+		steps []step
+	}{
+		{
+			name:  "limite désactivée",
+			rate:  0,
+			steps: []step{{0, ip, true}, {0, ip, true}, {0, ip, true}},
+		},
+		{
+			name:  "au-delà du débit",
+			rate:  2,
+			steps: []step{{0, ip, true}, {time.Second, ip, true}, {time.Second, ip, false}},
+		},
+		{
+			name:  "compteur par adresse",
+			rate:  1,
+			steps: []step{{0, ip, true}, {0, other, true}, {0, ip, false}, {0, other, false}},
+		},
+		{
+			name: "fenêtre glissante d'une minute",
+			rate: 2,
+			steps: []step{
+				{0, ip, true},
+				{30 * time.Second, ip, true},
+				{29 * time.Second, ip, false}, // La première date de 59 s
+				{2 * time.Second, ip, true},   // La première est sortie de la fenêtre
+				{0, ip, false},
+			},
+		},
+		{
+			name:  "les refus ne consomment pas la fenêtre",
+			rate:  1,
+			steps: []step{{0, ip, true}, {50 * time.Second, ip, false}, {11 * time.Second, ip, true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, c := newTestLimiter(Config{RatePerMinute: tt.rate})
+			rejected := 0
+			for i, step := range tt.steps {
+				c.t = c.t.Add(step.after)
+				reason, ok := l.AllowConn(step.ip)
+				if ok != step.want {
+					t.Fatalf("connexion %d: acceptée = %v, attendu %v", i+1, ok, step.want)
+				}
+				if !ok {
+					rejected++
+					if reason != RateLimited {
+						t.Errorf("connexion %d: motif %q, attendu %q", i+1, reason, RateLimited)
+					}
+				}
+			}
+			if got := l.Stats().Rejected[RateLimited]; got != rejected {
+				t.Errorf("%d refus comptés, attendu %d", got, rejected)
+			}
+		})
+	}
+}
+
+func TestAllowConnBanned(t *testing.T) {
+	_, banned, _ := net.ParseCIDR("198.51.100.0/24")
+	l, _ := newTestLimiter(Config{BannedNets: []*net.IPNet{banned}})
+	if reason, ok := l.AllowConn(net.ParseIP("198.51.100.7")); ok || reason != Banned {
+		t.Errorf("adresse bannie: %q, %v, attendu %q, false", reason, ok, Banned)
+	}
+	if _, ok := l.AllowConn(net.ParseIP("198.51.101.7")); !ok {
+		t.Error("adresse hors du réseau banni refusée")
+	}
+}
+
+func TestPruneForgetsExpiredAddresses(t *testing.T) {
+	l, c := newTestLimiter(Config{RatePerMinute: 1})
+	for i := range maxTracked + 1 {
+		l.AllowConn(net.IPv4(10, 0, byte(i>>8), byte(i)))
+	}
+	c.t = c.t.Add(time.Minute + time.Second)
+	l.AllowConn(net.ParseIP("192.0.2.1"))
+	if len(l.attempts) != 1 {
+		t.Errorf("%d adresses suivies après purge, attendu 1", len(l.attempts))
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	ip := net.ParseIP("192.0.2.1")
+	other := net.ParseIP("192.0.2.2")
+	type session struct {
+		ip       net.IP
+		identity string
+		exempt   bool
+		want     Reason // "" si la session est acceptée
+	}
+	tests := []struct {
+		name     string
+		cfg      Config
+		sessions []session
+	}{
+		{
+			name:     "sans limite",
+			sessions: []session{{ip, "a", false, ""}, {ip, "a", false, ""}, {ip, "", false, ""}},
+		},
+		{
+			name:     "sessions par IP",
+			cfg:      Config{MaxPerIP: 2},
+			sessions: []session{{ip, "a", false, ""}, {ip, "b", false, ""}, {ip, "c", false, TooManyIP}, {other, "c", false, ""}},
+		},
+		{
+			name:     "sessions par identité, toutes adresses confondues",
+			cfg:      Config{MaxPerIdentity: 1},
+			sessions: []session{{ip, "a", false, ""}, {other, "a", false, TooManyIdentity}, {other, "b", false, ""}},
+		},
+		{
+			name:     "sans identité, seule la limite par IP s'applique",
+			cfg:      Config{MaxPerIdentity: 1},
+			sessions: []session{{ip, "", false, ""}, {ip, "", false, ""}},
+		},
+		{
+			name:     "serveur plein, sauf pour un administrateur",
+			cfg:      Config{MaxSessions: 1},
+			sessions: []session{{ip, "a", false, ""}, {other, "b", false, Full}, {other, "admin", true, ""}},
+		},
+		{
+			name:     "un administrateur reste soumis à la limite par IP",
+			cfg:      Config{MaxPerIP: 1},
+			sessions: []session{{ip, "a", false, ""}, {ip, "admin", true, TooManyIP}},
+		},
+		{
+			name:     "identité bannie",
+			cfg:      Config{BannedIdentities: []string{"SHA256:mechant"}},
+			sessions: []session{{ip, "SHA256:mechant", true, Banned}, {ip, "SHA256:gentil", false, ""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := newTestLimiter(tt.cfg)
+			accepted := 0
+			for i, s := range tt.sessions {
+				release, reason, ok := l.Acquire(s.ip, s.identity, s.exempt)
+				if reason != s.want || ok != (s.want == "") {
+					t.Fatalf("session %d: %q, %v, attendu %q", i+1, reason, ok, s.want)
+				}
+				if ok {
+					accepted++
+					if release == nil {
+						t.Fatalf("session %d: acceptée sans fonction de libération", i+1)
+					}
+				}
+			}
+			stats := l.Stats()
+			if stats.Sessions != accepted || stats.Accepted != accepted {
+				t.Errorf("statistiques %+v, attendu %d sessions acceptées", stats, accepted)
+			}
+		})
+	}
+}
+
+func TestAcquireRelease(t *testing.T) {
+	ip := net.ParseIP("192.0.2.1")
+	l, _ := newTestLimiter(Config{MaxPerIP: 1, MaxPerIdentity: 1, MaxSessions: 1})
+
+	release, _, ok := l.Acquire(ip, "a", false)
+	if !ok {
+		t.Fatal("première session refusée")
+	}
+	if _, reason, ok := l.Acquire(ip, "a", false); ok {
+		t.Fatal("seconde session acceptée")
+	} else if reason != Full {
+		t.Errorf("motif %q, attendu %q", reason, Full)
+	}
+
+	release()
+	release() // Sans effet: la place n'est libérée qu'une fois
+	if s := l.Stats(); s.Sessions != 0 {
+		t.Errorf("%d session(s) ouverte(s) après libération, attendu 0", s.Sessions)
+	}
+	if len(l.perIP) != 0 || len(l.perIdentity) != 0 {
+		t.Errorf("compteurs non vidés: par IP %v, par identité %v", l.perIP, l.perIdentity)
+	}
+	if _, _, ok := l.Acquire(ip, "a", false); !ok {
+		t.Error("session refusée après libération de la place")
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"os"
+	"quizz-ssh/limiter"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// bansPath liste les adresses (IP ou réseau CIDR) et les clés SSH bannies
+const bansPath = "./data/bans"
+
+// Limites par défaut, modifiables par variables d'environnement (0 désactive)
+const (
+	defaultConnRate       = 20 // CONN_RATE_PER_MINUTE
+	defaultMaxPerIP       = 5  // MAX_SESSIONS_PER_IP
+	defaultMaxPerIdentity = 3  // MAX_SESSIONS_PER_IDENTITY
+	defaultMaxSessions    = 200
+)
+
+var limits *limiter.Limiter
+
+// loadLimits lit la configuration des limites et la liste des bannis
+func loadLimits() (*limiter.Limiter, error) {
+	var cfg limiter.Config
+	var err error
+	for _, v := range []struct {
+		key      string
+		fallback int
+		dst      *int
+	}{
+		{"CONN_RATE_PER_MINUTE", defaultConnRate, &cfg.RatePerMinute},
+		{"MAX_SESSIONS_PER_IP", defaultMaxPerIP, &cfg.MaxPerIP},
+		{"MAX_SESSIONS_PER_IDENTITY", defaultMaxPerIdentity, &cfg.MaxPerIdentity},
+		{"MAX_SESSIONS", defaultMaxSessions, &cfg.MaxSessions},
+	} {
+		if *v.dst, err = getenvInt(v.key, v.fallback); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.BannedNets, cfg.BannedIdentities, err = loadBans(getenv("BANS_FILE", bansPath))
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🛡️  Limites: %d connexion(s)/min par IP • %d session(s) par IP • %d par identité • %d au total • %d bannissement(s)",
+		cfg.RatePerMinute, cfg.MaxPerIP, cfg.MaxPerIdentity, cfg.MaxSessions, len(cfg.BannedNets)+len(cfg.BannedIdentities))
+	return limiter.New(cfg), nil
+}
+
+// loadBans lit la liste des bannis: une adresse IP, un réseau CIDR ou une clé
+// au format authorized_keys par ligne. Un fichier absent signifie aucun banni.
+func loadBans(path string) (nets []*net.IPNet, identities []string, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if ip := net.ParseIP(line); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(line); err == nil {
+			nets = append(nets, ipNet)
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s ligne %d: ni adresse, ni réseau, ni clé: %w", path, n, err)
+		}
+		identities = append(identities, gossh.FingerprintSHA256(key))
+	}
+	return nets, identities, scanner.Err()
+}
+
+// limitConn refuse, avant même la négociation SSH, les adresses bannies et
+// celles qui ouvrent trop de connexions
+func limitConn(ctx ssh.Context, conn net.Conn) net.Conn {
+	ip := remoteIP(conn.RemoteAddr())
+	if reason, ok := limits.AllowConn(ip); !ok {
+		if reason == limiter.Banned {
+			log.Printf("⛔ Connexion refusée (%s): %s", reason, ip)
+		}
+		conn.Close()
+		return nil
+	}
+	return conn
+}
+
+// limitsMiddleware applique les limites de sessions simultanées; le joueur
+// refusé reçoit un message dans sa langue avant la fermeture de la session
+func limitsMiddleware(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		ip := remoteIP(s.RemoteAddr())
+		release, reason, ok := limits.Acquire(ip, identity(s), isAdmin(s))
+		if !ok {
+			log.Printf("⛔ Session refusée (%s): %s %s", reason, ip, identity(s))
+			lang := sessionLang(s)
+			switch reason {
+			case limiter.Full:
+				wish.Fatalln(s, lang.T("limits.full"))
+			case limiter.TooManyIP:
+				wish.Fatalln(s, lang.T("limits.too_many_ip"))
+			case limiter.TooManyIdentity:
+				wish.Fatalln(s, lang.T("limits.too_many_identity"))
+			default:
+				wish.Fatalln(s, lang.T("limits.banned"))
+			}
+			return
+		}
+		defer release()
+		next(s)
+	}
+}
+
+// logLimits journalise régulièrement les compteurs, quand ils ont changé
+func logLimits(interval time.Duration) {
+	var last limiter.Stats
+	for range time.Tick(interval) {
+		stats := limits.Stats()
+		if stats.Accepted == last.Accepted && stats.Sessions == last.Sessions && sumRejected(stats) == sumRejected(last) {
+			continue
+		}
+		last = stats
+		log.Printf("🛡️  %d session(s) ouverte(s) • %d acceptée(s) • refus: %d débit, %d bannissement, %d par IP, %d par identité, %d serveur plein",
+			stats.Sessions, stats.Accepted, stats.Rejected[limiter.RateLimited], stats.Rejected[limiter.Banned],
+			stats.Rejected[limiter.TooManyIP], stats.Rejected[limiter.TooManyIdentity], stats.Rejected[limiter.Full])
+	}
+}
+
+func sumRejected(stats limiter.Stats) int {
+	total := 0
+	for _, n := range stats.Rejected {
+		total += n
+	}
+	return total
+}
+
+// identity identifie un joueur par l'empreinte de sa clé publique. Sans clé,
+// le nom d'utilisateur SSH est libre: seule la limite par IP s'applique.
+func identity(s ssh.Session) string {
+	if key := s.PublicKey(); key != nil {
+		return gossh.FingerprintSHA256(key)
+	}
+	return ""
+}
+
+// remoteIP extrait l'adresse IP d'une adresse réseau "ip:port"
+func remoteIP(addr net.Addr) net.IP {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,409 @@
+package lobby
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrPlayerUnavailable = errors.New("joueur indisponible")
+	ErrUnknownPlayer     = errors.New("joueur inconnu")
+	ErrSameUsername      = errors.New("même pseudo que l'adversaire")
+)
+
+// PlayerInfo décrit un joueur connecté tel qu'affiché dans la liste des adversaires
+type PlayerInfo struct {
+	ID       string
+	Username string
+}
+
+// Messages envoyés aux programmes bubbletea des joueurs
+type (
+	// PlayersChangedMsg signale que la liste des joueurs disponibles a changé
+	PlayersChangedMsg struct{}
+
+	// InviteMsg est reçu par le joueur défié
+	InviteMsg struct {
+		FromID string
+		From   string
+	}
+
+	// DeclinedMsg est reçu par le challenger quand le défi est refusé ou annulé
+	DeclinedMsg struct {
+		By string
+	}
+
+	// StartMsg est reçu par les deux joueurs au lancement du duel
+	StartMsg struct {
+		DuelID   int64
+		Seed     int64
+		Opponent string
+	}
+
+	// ProgressMsg transmet la progression de l'adversaire
+	ProgressMsg struct {
+		DuelID   int64
+		Answered int
+		Score    int
+		Total    int
+	}
+
+	// ResultMsg annonce la fin du duel aux deux joueurs
+	ResultMsg struct {
+		Result Result
+	}
+)
+
+// Result est le résultat final d'un duel
+type Result struct {
+	DuelID   int64
+	Players  [2]string
+	Scores   [2]int
+	Total    int
+	Winner   int  // index du vainqueur dans Players, -1 en cas d'égalité parfaite
+	Forfeit  bool // un des joueurs a quitté avant la fin
+	Category string
+}
+
+// WinnerName retourne le pseudo du vainqueur, vide en cas d'égalité parfaite
+func (r Result) WinnerName() string {
+	if r.Winner < 0 {
+		return ""
+	}
+	return r.Players[r.Winner]
+}
+
+type progress struct {
+	answered   int
+	score      int
+	total      int
+	finishedAt time.Time
+}
+
+type duel struct {
+	id       int64
+	seed     int64
+	category string
+	players  [2]*player
+	progress [2]progress
+	over     bool
+}
+
+type player struct {
+	id        string
+	username  string
+	send      func(msg any)
+	available bool
+	inviting  string // ID du joueur défié en attente de réponse
+	invitedBy string // ID du challenger en attente de notre réponse
+	duel      *duel
+}
+
+// Lobby référence les joueurs connectés et orchestre les duels
+type Lobby struct {
+	mu       sync.Mutex
+	players  map[string]*player
+	nextID   int64
+	category string
+	onFinish func(Result)
+}
+
+// New crée un lobby; onFinish est appelé une fois par duel terminé
+func New(category string, onFinish func(Result)) *Lobby {
+	return &Lobby{
+		players:  make(map[string]*player),
+		category: category,
+		onFinish: onFinish,
+	}
+}
+
+// deliver envoie un message hors du verrou et sans bloquer l'appelant:
+// p.Send d'un programme bubbletea bloque tant que sa boucle n'a pas consommé le message
+func deliver(send func(msg any), msg any) {
+	if send != nil {
+		go send(msg)
+	}
+}
+
+// Join enregistre un joueur connecté
+func (l *Lobby) Join(id, username string, send func(msg any)) {
+	l.mu.Lock()
+	l.players[id] = &player{id: id, username: username, send: send}
+	l.mu.Unlock()
+}
+
+// Leave retire un joueur; un duel en cours est perdu par forfait
+func (l *Lobby) Leave(id string) {
+	l.mu.Lock()
+	p, ok := l.players[id]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.players, id)
+
+	var notify []func()
+	if p.inviting != "" {
+		if target, ok := l.players[p.inviting]; ok {
+			target.invitedBy = ""
+			send := target.send
+			notify = append(notify, func() { deliver(send, DeclinedMsg{By: p.username}) })
+		}
+	}
+	if p.invitedBy != "" {
+		if from, ok := l.players[p.invitedBy]; ok {
+			from.inviting = ""
+			send := from.send
+			notify = append(notify, func() { deliver(send, DeclinedMsg{By: p.username}) })
+		}
+	}
+
+	var result *Result
+	var others []*player
+	if d := p.duel; d != nil && !d.over {
+		idx := 0
+		if d.players[1] == p {
+			idx = 1
+		}
+		// Un joueur qui a terminé ses questions attend simplement l'adversaire
+		if d.progress[idx].finishedAt.IsZero() {
+			res := l.finishLocked(d, 1-idx)
+			result = &res
+			others = append(others, d.players[1-idx])
+		}
+	}
+	changed := p.available
+	var everyone []func(any)
+	if changed {
+		everyone = l.sendersLocked()
+	}
+	l.mu.Unlock()
+
+	for _, n := range notify {
+		n()
+	}
+	if result != nil {
+		for _, o := range others {
+			deliver(o.send, ResultMsg{Result: *result})
+		}
+		if l.onFinish != nil {
+			l.onFinish(*result)
+		}
+	}
+	for _, send := range everyone {
+		deliver(send, PlayersChangedMsg{})
+	}
+}
+
+// SetAvailable indique si le joueur peut recevoir un défi (il est sur le menu)
+func (l *Lobby) SetAvailable(id string, available bool) {
+	l.mu.Lock()
+	p, ok := l.players[id]
+	if !ok || p.available == available {
+		l.mu.Unlock()
+		return
+	}
+	p.available = available
+	everyone := l.sendersLocked()
+	l.mu.Unlock()
+
+	for _, send := range everyone {
+		deliver(send, PlayersChangedMsg{})
+	}
+}
+
+// Available liste les joueurs pouvant être défiés, hors exceptID et les autres
+// sessions sous le même pseudo
+func (l *Lobby) Available(exceptID string) []PlayerInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var username string
+	if self, ok := l.players[exceptID]; ok {
+		username = self.username
+	}
+	var infos []PlayerInfo
+	for _, p := range l.players {
+		if p.id != exceptID && p.username != username && p.available && p.invitedBy == "" && p.inviting == "" && p.duel == nil {
+			infos = append(infos, PlayerInfo{ID: p.id, Username: p.username})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Username < infos[j].Username
+	})
+	return infos
+}
+
+// Challenge envoie un défi de fromID à toID
+func (l *Lobby) Challenge(fromID, toID string) error {
+	l.mu.Lock()
+	from, ok := l.players[fromID]
+	to, ok2 := l.players[toID]
+	if !ok || !ok2 {
+		l.mu.Unlock()
+		return ErrUnknownPlayer
+	}
+	// Deux sessions sous le même pseudo ne se distinguent ni dans les résultats ni dans les niveaux
+	if from.username == to.username {
+		l.mu.Unlock()
+		return ErrSameUsername
+	}
+	if from.duel != nil || !to.available || to.invitedBy != "" || to.inviting != "" || to.duel != nil {
+		l.mu.Unlock()
+		return ErrPlayerUnavailable
+	}
+	from.inviting = to.id
+	to.invitedBy = from.id
+	send := to.send
+	l.mu.Unlock()
+
+	deliver(send, InviteMsg{FromID: from.id, From: from.username})
+	return nil
+}
+
+// Cancel annule le défi en attente lancé par fromID
+func (l *Lobby) Cancel(fromID string) {
+	l.mu.Lock()
+	from, ok := l.players[fromID]
+	if !ok || from.inviting == "" {
+		l.mu.Unlock()
+		return
+	}
+	var send func(any)
+	if to, ok := l.players[from.inviting]; ok {
+		to.invitedBy = ""
+		send = to.send
+	}
+	from.inviting = ""
+	username := from.username
+	l.mu.Unlock()
+
+	deliver(send, DeclinedMsg{By: username})
+}
+
+// Respond accepte ou refuse le défi reçu par toID
+func (l *Lobby) Respond(toID string, accept bool) {
+	l.mu.Lock()
+	to, ok := l.players[toID]
+	if !ok || to.invitedBy == "" {
+		l.mu.Unlock()
+		return
+	}
+	from, ok := l.players[to.invitedBy]
+	to.invitedBy = ""
+	if !ok || from.inviting != to.id {
+		l.mu.Unlock()
+		return
+	}
+	from.inviting = ""
+
+	if !accept {
+		send := from.send
+		l.mu.Unlock()
+		deliver(send, DeclinedMsg{By: to.username})
+		return
+	}
+
+	l.nextID++
+	d := &duel{
+		id:       l.nextID,
+		seed:     time.Now().UnixNano(),
+		category: l.category,
+		players:  [2]*player{from, to},
+	}
+	from.duel, to.duel = d, d
+	l.mu.Unlock()
+
+	deliver(from.send, StartMsg{DuelID: d.id, Seed: d.seed, Opponent: to.username})
+	deliver(to.send, StartMsg{DuelID: d.id, Seed: d.seed, Opponent: from.username})
+}
+
+// Report met à jour la progression d'un joueur en duel et la transmet à l'adversaire
+func (l *Lobby) Report(id string, answered, score, total int) {
+	l.mu.Lock()
+	p, ok := l.players[id]
+	if !ok || p.duel == nil || p.duel.over {
+		l.mu.Unlock()
+		return
+	}
+	d := p.duel
+	idx := 0
+	if d.players[1] == p {
+		idx = 1
+	}
+	pr := &d.progress[idx]
+	pr.answered, pr.score, pr.total = answered, score, total
+	if answered >= total && pr.finishedAt.IsZero() {
+		pr.finishedAt = time.Now()
+	}
+	opponent := d.players[1-idx]
+
+	var result *Result
+	if !pr.finishedAt.IsZero() && !d.progress[1-idx].finishedAt.IsZero() {
+		res := l.finishLocked(d, -1)
+		result = &res
+	}
+	l.mu.Unlock()
+
+	deliver(opponent.send, ProgressMsg{DuelID: d.id, Answered: answered, Score: score, Total: total})
+	if result != nil {
+		deliver(d.players[0].send, ResultMsg{Result: *result})
+		deliver(d.players[1].send, ResultMsg{Result: *result})
+		if l.onFinish != nil {
+			l.onFinish(*result)
+		}
+	}
+}
+
+// releaseLocked détache les deux joueurs d'un duel terminé
+func releaseLocked(d *duel) {
+	for _, p := range d.players {
+		if p.duel == d {
+			p.duel = nil
+		}
+	}
+}
+
+// finishLocked clôt le duel; winnerIdx >= 0 force le vainqueur (forfait)
+func (l *Lobby) finishLocked(d *duel, winnerIdx int) Result {
+	d.over = true
+	releaseLocked(d)
+	res := Result{
+		DuelID:   d.id,
+		Players:  [2]string{d.players[0].username, d.players[1].username},
+		Scores:   [2]int{d.progress[0].score, d.progress[1].score},
+		Total:    max(d.progress[0].total, d.progress[1].total),
+		Winner:   -1,
+		Forfeit:  winnerIdx >= 0,
+		Category: d.category,
+	}
+
+	if winnerIdx < 0 {
+		a, b := d.progress[0], d.progress[1]
+		switch {
+		case a.score > b.score:
+			winnerIdx = 0
+		case b.score > a.score:
+			winnerIdx = 1
+		case a.finishedAt.Before(b.finishedAt):
+			// Égalité: le plus rapide l'emporte
+			winnerIdx = 0
+		case b.finishedAt.Before(a.finishedAt):
+			winnerIdx = 1
+		}
+	}
+	if winnerIdx >= 0 {
+		res.Winner = winnerIdx
+	}
+	return res
+}
+
+func (l *Lobby) sendersLocked() []func(any) {
+	senders := make([]func(any), 0, len(l.players))
+	for _, p := range l.players {
+		senders = append(senders, p.send)
+	}
+	return senders
+}
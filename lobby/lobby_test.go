@@ -0,0 +1,77 @@
+package lobby
+
+import (
+	"errors"
+	"testing"
+)
+
+// startDuel connecte deux joueurs et lance un duel de alice (challenger) contre bob
+func startDuel(t *testing.T, l *Lobby) {
+	t.Helper()
+	ignore := func(any) {}
+	l.Join("a", "alice", ignore)
+	l.Join("b", "bob", ignore)
+	l.SetAvailable("b", true)
+	if err := l.Challenge("a", "b"); err != nil {
+		t.Fatalf("Challenge: %v", err)
+	}
+	l.Respond("b", true)
+}
+
+func TestResultWinner(t *testing.T) {
+	tests := []struct {
+		name       string
+		play       func(l *Lobby)
+		winner     int
+		winnerName string
+	}{
+		{"challenger meilleur score", func(l *Lobby) { l.Report("a", 5, 4, 5); l.Report("b", 5, 2, 5) }, 0, "alice"},
+		{"défié meilleur score", func(l *Lobby) { l.Report("a", 5, 1, 5); l.Report("b", 5, 3, 5) }, 1, "bob"},
+		{"forfait du challenger", func(l *Lobby) { l.Report("a", 2, 2, 5); l.Leave("a") }, 1, "bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []Result
+			l := New("Duel", func(res Result) { results = append(results, res) })
+			startDuel(t, l)
+			tt.play(l)
+
+			if len(results) != 1 {
+				t.Fatalf("%d résultat(s), attendu 1", len(results))
+			}
+			if res := results[0]; res.Winner != tt.winner || res.WinnerName() != tt.winnerName {
+				t.Errorf("vainqueur %d (%q), attendu %d (%q)", res.Winner, res.WinnerName(), tt.winner, tt.winnerName)
+			}
+		})
+	}
+}
+
+func TestResultDraw(t *testing.T) {
+	res := Result{Players: [2]string{"alice", "bob"}, Winner: -1}
+	if name := res.WinnerName(); name != "" {
+		t.Errorf("WinnerName() = %q, attendu vide pour une égalité", name)
+	}
+}
+
+// Deux sessions sous le même pseudo ne peuvent pas s'affronter
+func TestSameUsernameCannotDuel(t *testing.T) {
+	l := New("Duel", nil)
+	ignore := func(any) {}
+	l.Join("a1", "alice", ignore)
+	l.Join("a2", "alice", ignore)
+	l.Join("b", "bob", ignore)
+	for _, id := range []string{"a1", "a2", "b"} {
+		l.SetAvailable(id, true)
+	}
+
+	available := l.Available("a1")
+	if len(available) != 1 || available[0].ID != "b" {
+		t.Errorf("Available(a1) = %v, attendu seulement bob", available)
+	}
+	if err := l.Challenge("a1", "a2"); !errors.Is(err, ErrSameUsername) {
+		t.Errorf("Challenge(a1, a2): %v, attendu %v", err, ErrSameUsername)
+	}
+	if err := l.Challenge("a1", "b"); err != nil {
+		t.Errorf("Challenge(a1, b): %v", err)
+	}
+}
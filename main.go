@@ -5,33 +5,44 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"quizz-ssh/achievements"
+	"quizz-ssh/i18n"
+	"quizz-ssh/lobby"
 	"quizz-ssh/models"
+	"quizz-ssh/rating"
 	"quizz-ssh/storage"
 	"quizz-ssh/ui"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Fuseaux embarqués: l'image alpine n'a pas de tzdata
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/activeterm"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 const (
-	host         = "0.0.0.0"
-	port         = 2222
-	dbPath       = "./data/quiz.db"
+	host          = "0.0.0.0"
+	port          = 2222
+	dbPath        = "./data/quiz.db"
 	questionsPath = "./questions.json"
+
+	duelQuestionCount = 10
+	profileHistory    = 20
 )
 
 var (
-	db        *storage.Database
-	questions []models.Question
+	db      storage.Store
+	players *lobby.Lobby
 )
 
 func main() {
@@ -40,31 +51,108 @@ func main() {
 		log.Fatalf("Erreur création dossier data: %v", err)
 	}
 
-	// Initialiser la base de données
+	// Sous-commandes d'administration (ex: quiz-server migrate status)
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
+	if lang, ok := i18n.Parse(getenv("DEFAULT_LANG", string(i18n.Default))); ok {
+		i18n.Default = lang
+	} else {
+		log.Fatalf("Langue par défaut inconnue: %s", os.Getenv("DEFAULT_LANG"))
+	}
 	var err error
-	db, err = storage.NewDatabase(dbPath)
+	for _, v := range []struct {
+		key      string
+		fallback time.Duration
+		dst      *time.Duration
+	}{
+		{"RESUME_WINDOW", defaultResumeWindow, &resumeWindow},
+		{"IDLE_TIMEOUT", defaultIdleTimeout, &idleTimeout},
+		{"MAX_SESSION_DURATION", defaultMaxSessionDuration, &maxSessionDuration},
+		{"SHUTDOWN_GRACE", defaultShutdownGrace, &shutdownGrace},
+		{"ANNOUNCEMENT_DURATION", defaultAnnouncementDuration, &announcementDuration},
+	} {
+		if *v.dst, err = getenvDuration(v.key, v.fallback); err != nil {
+			log.Fatalf("Erreur configuration: %v", err)
+		}
+	}
+
+	// Initialiser la base de données; au premier démarrage, la banque de
+	// questions est importée du fichier et la base fait ensuite référence
+	store, err := storage.NewStore(databaseDSN(), seedQuestions)
 	if err != nil {
 		log.Fatalf("Erreur connexion DB: %v", err)
 	}
+	db = notifyingStore{Store: store}
 	defer db.Close()
 
-	// Charger les questions
-	questions, err = storage.LoadQuestions(questionsPath)
+	// Les parties interrompues depuis trop longtemps ne peuvent plus être
+	// reprises; sans délai de reprise, toute partie interrompue est abandonnée
+	go sweepProgress(time.Minute)
+
+	log.Printf("✅ %d questions dans la banque", len(loadQuestions()))
+
+	players = lobby.New("Duel", saveDuel)
+
+	adminKeys, err = loadAdminKeys(getenv("ADMIN_KEYS_FILE", adminKeysPath))
 	if err != nil {
-		log.Printf("⚠️  Erreur chargement questions: %v", err)
-		log.Printf("ℹ️  Utilisation de questions par défaut")
-		questions = getDefaultQuestions()
+		log.Fatalf("Erreur chargement clés admin: %v", err)
+	}
+	log.Printf("🔑 %d clé(s) administrateur", len(adminKeys))
+
+	limits, err = loadLimits()
+	if err != nil {
+		log.Fatalf("Erreur configuration limites: %v", err)
+	}
+	go logLimits(5 * time.Minute)
+
+	scheduled, err := loadAnnouncements(getenv("ANNOUNCEMENTS_FILE", announcementsPath))
+	if err != nil {
+		log.Fatalf("Erreur chargement annonces programmées: %v", err)
+	}
+	if len(scheduled) > 0 {
+		log.Printf("📢 %d annonce(s) programmée(s)", len(scheduled))
+		go scheduleAnnouncements(scheduled)
 	}
 
-	log.Printf("✅ %d questions chargées", len(questions))
+	// Métriques Prometheus, seulement sur leur propre port (METRICS_ADDR): jamais
+	// sur le port public de la page des classements
+	registerMetrics()
+	var metricsServer *http.Server
+	if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+		metricsPath := getenv("METRICS_PATH", defaultMetricsPath)
+		if !strings.HasPrefix(metricsPath, "/") {
+			log.Fatalf("METRICS_PATH invalide: %s", metricsPath)
+		}
+		metricsServer = startMetrics(addr, metricsPath)
+	}
+
+	// Page des classements et API HTTP optionnelles, sur la même base que le serveur SSH
+	var httpServer *http.Server
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		if httpServer, err = startHTTP(addr); err != nil {
+			log.Fatalf("Erreur configuration API HTTP: %v", err)
+		}
+	}
 
 	// Configuration du serveur SSH
 	s, err := wish.NewServer(
 		wish.WithAddress(fmt.Sprintf("%s:%d", host, port)),
 		wish.WithHostKeyPath(".ssh/id_ed25519"),
+		// Tout le monde peut jouer: la clé publique, si le client en présente une,
+		// sert seulement à reconnaître les administrateurs
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
+		ssh.WrapConn(limitConn),
 		wish.WithMiddleware(
-			bubbletea.Middleware(teaHandler),
+			bubbletea.MiddlewareWithProgramHandler(programHandler, termenv.Ascii),
+			sessionMiddleware,
 			activeterm.Middleware(),
+			limitsMiddleware,
 			logging.Middleware(),
 		),
 	)
@@ -85,30 +173,205 @@ func main() {
 	}()
 
 	<-done
-	log.Println("🛑 Arrêt du serveur...")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Prévenir les joueurs: chaque session enregistre sa partie et se ferme à l'échéance
+	deadline := time.Now().Add(shutdownGrace)
+	log.Printf("🛑 Arrêt du serveur dans %s (%d session(s) ouverte(s))...", shutdownGrace, sessions.Count())
+	sessions.Broadcast(maintenanceMsg{Deadline: deadline})
+
+	// Shutdown refuse aussitôt les nouvelles connexions puis attend la fin des sessions
+	ctx, cancel := context.WithDeadline(context.Background(), deadline.Add(shutdownMargin))
 	defer cancel()
 	if err := s.Shutdown(ctx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
-		log.Fatalf("Erreur arrêt serveur: %v", err)
+		log.Printf("⚠️  %d session(s) encore ouverte(s) à l'échéance, fermeture forcée", sessions.Count())
+		s.Close()
+	}
+	if httpServer != nil {
+		stopHTTP(httpServer)
 	}
+	if metricsServer != nil {
+		stopHTTP(metricsServer)
+	}
+	log.Println("👋 Serveur arrêté")
 }
 
-func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+func programHandler(s ssh.Session) *tea.Program {
 	pty, _, active := s.Pty()
 	if !active {
 		wish.Fatalln(s, "no active terminal")
-		return nil, nil
+		return nil
 	}
 
 	// Modèle initial: demander le pseudo
 	m := &appModel{
 		session: s,
+		id:      s.Context().SessionID(),
+		admin:   isAdmin(s),
+		lang:    sessionLang(s),
+		styles:  sessionStyles(s).Resize(pty.Window.Width, pty.Window.Height),
 		width:   pty.Window.Width,
 		height:  pty.Window.Height,
 		state:   stateUsername,
+
+		startedAt: time.Now(),
+		lastInput: time.Now(),
+
+		// Les annonces en cours s'affichent aussi aux joueurs arrivés après
+		announcements: announcementBoard.Active(),
 	}
 
-	return m, []tea.ProgramOption{tea.WithAltScreen()}
+	// Les signaux concernent le serveur: l'arrêt prévient les joueurs avant de fermer leur session
+	opts := append([]tea.ProgramOption{tea.WithAltScreen(), tea.WithoutSignalHandler()}, bubbletea.MakeOptions(s)...)
+	p := tea.NewProgram(m, opts...)
+	m.send = func(msg any) { p.Send(msg) }
+	sessions.Add(m.id, p)
+	return p
+}
+
+// sessionMiddleware retire le joueur du lobby et du registre des sessions à la
+// déconnexion, et abandonne la partie en cours que personne ne pourra reprendre
+func sessionMiddleware(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		id := s.Context().SessionID()
+		defer players.Leave(id)
+		defer func() {
+			abandonUnresumable(s, sessions.Progress(id))
+			sessions.Remove(id)
+		}()
+		next(s)
+	}
+}
+
+// saveDuel persiste le résultat d'un duel terminé
+func saveDuel(res lobby.Result) {
+	duel := models.Duel{
+		Player1: res.Players[0],
+		Player2: res.Players[1],
+		Score1:  res.Scores[0],
+		Score2:  res.Scores[1],
+		Total:   res.Total,
+		Winner:  res.WinnerName(),
+		Forfeit: res.Forfeit,
+	}
+	if err := db.SaveDuel(duel); err != nil {
+		log.Printf("Erreur sauvegarde duel: %v", err)
+	}
+	if res.Forfeit {
+		quizzesAbandoned.WithLabelValues("duel").Inc()
+	}
+
+	score := 0.5
+	switch res.Winner {
+	case 0:
+		score = 1
+	case 1:
+		score = 0
+	}
+	// Les questions d'un duel mêlent toutes les catégories: seul le niveau global change
+	err := db.UpdateRatings("global", "duel", res.Players[:], func(current []models.Rating) []float64 {
+		a, b := current[0], current[1]
+		newA, newB := rating.Duel(a.Rating, b.Rating, a.Games, b.Games, score)
+		return []float64{newA, newB}
+	})
+	if err != nil {
+		log.Printf("Erreur sauvegarde niveaux duel: %v", err)
+	}
+}
+
+// seedQuestions fournit les questions de questions.json (ou les questions par
+// défaut), importées si la banque n'a jamais eu de question
+func seedQuestions() []models.Question {
+	questions, err := storage.LoadQuestions(questionsPath)
+	if err != nil {
+		log.Printf("⚠️  Erreur chargement questions: %v", err)
+		log.Printf("ℹ️  Utilisation de questions par défaut")
+		return getDefaultQuestions()
+	}
+	return questions
+}
+
+// loadQuestions récupère la version courante des questions actives,
+// la banque pouvant être modifiée pendant que le serveur tourne
+func loadQuestions() []models.Question {
+	all, err := db.GetQuestions()
+	if err != nil {
+		log.Printf("Erreur chargement questions: %v", err)
+	}
+
+	// Les questions désactivées par un administrateur ne sont plus posées
+	var questions []models.Question
+	for _, q := range all {
+		if !q.Disabled {
+			questions = append(questions, q)
+		}
+	}
+	observeQuestionBank(len(questions), err)
+	if len(questions) == 0 {
+		return getDefaultQuestions()
+	}
+	return questions
+}
+
+// unlockAchievements évalue les badges après une partie et enregistre les nouveaux
+func unlockAchievements(score models.Score) []achievements.Achievement {
+	owned, err := db.GetUserAchievements(score.Username)
+	if err != nil {
+		log.Printf("Erreur récupération badges: %v", err)
+		return nil
+	}
+	unlocked := make(map[string]bool, len(owned))
+	for _, a := range owned {
+		unlocked[a.Code] = true
+	}
+
+	ctx := achievements.Context{
+		Score:      score,
+		Categories: storage.GetUniqueCategories(loadQuestions()),
+	}
+	ctx.Stats, err = db.GetUserStats(score.Username)
+	if err == nil {
+		ctx.CategoryRates, err = db.GetUserCategoryRates(score.Username)
+	}
+	if err == nil {
+		ctx.Streak, err = db.GetPlayStreak(score.Username)
+	}
+	if err != nil {
+		log.Printf("Erreur évaluation badges: %v", err)
+		return nil
+	}
+
+	var earned []achievements.Achievement
+	for _, a := range achievements.Evaluate(ctx, unlocked) {
+		if err := db.UnlockAchievement(score.Username, a.Code); err != nil {
+			log.Printf("Erreur enregistrement badge %s: %v", a.Code, err)
+			continue
+		}
+		log.Printf("🏅 %s débloque le badge %s", score.Username, a.Code)
+		earned = append(earned, a)
+	}
+	return earned
+}
+
+// updateAttemptRatings met à jour les niveaux global et de la catégorie après une partie
+func updateAttemptRatings(score models.Score, answers []models.Answer) {
+	// La difficulté est celle de la version posée: la question a pu être
+	// modifiée ou désactivée depuis
+	outcomes := make([]rating.Outcome, len(answers))
+	for i, a := range answers {
+		q, err := db.GetQuestionRevision(a.QuestionID, a.Revision)
+		if err != nil {
+			log.Printf("Erreur récupération question #%d (version %d): %v", a.QuestionID, a.Revision, err)
+		}
+		outcomes[i] = rating.Outcome{Difficulty: q.Difficulty, Correct: a.Correct}
+	}
+
+	for _, category := range []string{"global", score.Category} {
+		err := db.UpdateRatings(category, "quiz", []string{score.Username}, func(current []models.Rating) []float64 {
+			return []float64{rating.Attempt(current[0].Rating, current[0].Games, outcomes)}
+		})
+		if err != nil {
+			log.Printf("Erreur sauvegarde niveau: %v", err)
+		}
+	}
 }
 
 type appState int
@@ -118,35 +381,179 @@ const (
 	stateMenu
 	stateQuiz
 	stateLeaderboard
+	stateRatingLeaderboard
+	stateProfile
+	stateDuelLobby
+	stateDuelInvite
+	stateDuelWait
+	stateDuel
+	stateAdmin
+	stateSettings
+	stateResume
 )
 
+// appStates nomme chaque état, pour les métriques
+var appStates = [...]string{
+	stateUsername:          "username",
+	stateMenu:              "menu",
+	stateQuiz:              "quiz",
+	stateLeaderboard:       "leaderboard",
+	stateRatingLeaderboard: "rating_leaderboard",
+	stateProfile:           "profile",
+	stateDuelLobby:         "duel_lobby",
+	stateDuelInvite:        "duel_invite",
+	stateDuelWait:          "duel_wait",
+	stateDuel:              "duel",
+	stateAdmin:             "admin",
+	stateSettings:          "settings",
+	stateResume:            "resume",
+}
+
+func (s appState) String() string {
+	if int(s) < len(appStates) {
+		return appStates[s]
+	}
+	return "unknown"
+}
+
 type appModel struct {
 	session  ssh.Session
+	id       string
+	send     func(msg any)
+	admin    bool       // connecté avec une clé d'administrateur
+	lang     i18n.Lang  // langue de l'interface
+	styles   *ui.Styles // thème et rendu adaptés au terminal du joueur
+	prefs    models.Preferences
 	width    int
 	height   int
+	viewport ui.Viewport
+
+	startedAt   time.Time // ouverture de la session
+	lastInput   time.Time // dernière touche ou action de la souris
+	idleWarning bool      // avertissement d'inactivité affiché
+	closing     string    // message affiché à la déconnexion automatique
+	maintenance time.Time // arrêt du serveur annoncé pour cette échéance
+
+	announcements []announcement // annonces reçues, affichées en bandeau
+
 	state    appState
 	username string
 	category string
 	subModel tea.Model
+
+	scoreSaved bool            // score du quiz en cours déjà enregistré
+	progress   models.Progress // partie solo en cours, enregistrée après chaque réponse
+
+	// Duel en cours
+	opponent string
+	duelID   int64
+	duelSeed int64
 }
 
 func (m *appModel) Init() tea.Cmd {
-	return nil
+	return sessionTick(sessionCheckInterval)
 }
 
 func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// L'écran courant de chaque session alimente les métriques, sa partie en
+	// cours la protège du balayage des parties interrompues
+	defer func() {
+		sessions.SetState(m.id, m.state)
+		sessions.SetProgress(m.id, m.progress.ID)
+	}()
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+
+	case sessionTickMsg:
+		return m.checkSession()
+
+	case maintenanceMsg:
+		m.maintenance = msg.Deadline
+		return m.checkMaintenance()
+
+	case maintenanceTickMsg:
+		return m.checkMaintenance()
+
+	case announcementMsg:
+		m.receiveAnnouncement(msg.Announcement)
+		return m, nil
+
+	case ui.AnnounceMsg:
+		if m.admin {
+			announce(msg.Text, m.username)
+		}
+		return m, nil
 
 	case tea.KeyMsg:
-		if msg.String() == "ctrl+c" {
+		m.lastInput = time.Now()
+		if m.idleWarning && msg.String() != "ctrl+c" {
+			// La touche sert seulement à rester connecté
+			m.idleWarning = false
+			return m, nil
+		}
+		switch msg.String() {
+		case "ctrl+c":
 			return m, tea.Quit
+		case "ctrl+x":
+			if m.dismissAnnouncements() {
+				return m, nil
+			}
+		case "shift+up":
+			m.viewport = m.viewport.Scroll(m.screen(), m.bodyHeight(), -max(1, m.height/2))
+			return m, nil
+		case "shift+down":
+			m.viewport = m.viewport.Scroll(m.screen(), m.bodyHeight(), max(1, m.height/2))
+			return m, nil
+		}
+
+	case tea.MouseMsg:
+		m.lastInput = time.Now()
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.viewport = m.viewport.Scroll(m.screen(), m.bodyHeight(), -3)
+			return m, nil
+		case tea.MouseButtonWheelDown:
+			m.viewport = m.viewport.Scroll(m.screen(), m.bodyHeight(), 3)
+			return m, nil
 		}
+		// Un clic sur les bandeaux masque les annonces
+		bannerHeight := m.height - m.bodyHeight()
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft && msg.Y < bannerHeight && m.dismissAnnouncements() {
+			return m, nil
+		}
+		// Les écrans raisonnent en lignes de leur rendu complet, avant défilement
+		msg.Y += m.viewport.Offset(m.screen(), m.bodyHeight()) - (m.height - m.bodyHeight())
+		return m.updateState(msg)
+
+	case lobby.InviteMsg, lobby.DeclinedMsg, lobby.StartMsg,
+		lobby.ProgressMsg, lobby.ResultMsg, lobby.PlayersChangedMsg:
+		return m.handleLobby(msg)
+
+	case ui.ReportMsg:
+		if err := db.SaveReport(msg.Report); err != nil {
+			log.Printf("Erreur enregistrement signalement: %v", err)
+		} else {
+			log.Printf("🚩 %s signale la question #%d", msg.Report.Username, msg.Report.QuestionID)
+		}
+		return m, nil
 	}
 
-	// State machine
+	// Un écran plus haut que le terminal défile pour garder la sélection visible
+	previous := m.state
+	model, cmd := m.updateState(msg)
+	if m.state != previous {
+		m.viewport = ui.Viewport{}
+	}
+	m.viewport = m.viewport.Follow(m.screen(), m.bodyHeight())
+	return model, cmd
+}
+
+// updateState transmet le message à l'écran de l'état courant
+func (m *appModel) updateState(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case stateUsername:
 		return m.updateUsername(msg)
@@ -156,6 +563,22 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateQuiz(msg)
 	case stateLeaderboard:
 		return m.updateLeaderboard(msg)
+	case stateRatingLeaderboard:
+		return m.updateRatingLeaderboard(msg)
+	case stateProfile:
+		return m.updateProfile(msg)
+	case stateDuelLobby:
+		return m.updateDuelLobby(msg)
+	case stateDuelInvite, stateDuelWait:
+		return m.updateDuelPrompt(msg)
+	case stateDuel:
+		return m.updateDuel(msg)
+	case stateAdmin:
+		return m.updateAdmin(msg)
+	case stateSettings:
+		return m.updateSettings(msg)
+	case stateResume:
+		return m.updateResume(msg)
 	}
 
 	return m, nil
@@ -163,7 +586,7 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *appModel) updateUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.subModel == nil {
-		m.subModel = ui.NewUsernameModel()
+		m.subModel = ui.NewUsernameModel(m.lang, m.styles)
 		return m, m.subModel.Init()
 	}
 
@@ -175,9 +598,15 @@ func (m *appModel) updateUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if usernameModel.IsDone() {
 			// Pseudo validé, passer au menu
 			m.username = usernameModel.GetUsername()
+			m.applyPreferences()
+			players.Join(m.id, m.username, m.send)
 			m.state = stateMenu
+			if m.openProgress() {
+				// Partie interrompue par une déconnexion: proposer de la reprendre
+				m.state = stateResume
+			}
 			m.subModel = nil
-			return m, nil
+			return m, m.mouseCmd()
 		}
 	}
 
@@ -186,7 +615,8 @@ func (m *appModel) updateUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *appModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.subModel == nil {
-		m.subModel = ui.NewMenuModel(m.username)
+		m.subModel = ui.NewMenuModel(m.lang, m.styles, m.username, m.admin)
+		players.SetAvailable(m.id, true)
 		return m.updateMenu(msg)
 	}
 
@@ -198,20 +628,27 @@ func (m *appModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if menuModel.IsDone() {
 			choice := menuModel.GetChoice()
 			m.subModel = nil
-
-			log.Printf("DEBUG: Menu choice = %d", choice)
+			players.SetAvailable(m.id, false)
 
 			switch choice {
 			case ui.MenuQuiz:
-				log.Printf("DEBUG: Switching to quiz state")
 				m.category = "Cybersecurity"
 				m.state = stateQuiz
 			case ui.MenuLeaderboard:
-				log.Printf("DEBUG: Switching to leaderboard state")
 				m.category = "global"
 				m.state = stateLeaderboard
+			case ui.MenuRating:
+				m.state = stateRatingLeaderboard
+			case ui.MenuProfile:
+				m.state = stateProfile
+			case ui.MenuDuel:
+				m.state = stateDuelLobby
+			case ui.MenuSettings:
+				m.state = stateSettings
+			case ui.MenuAdmin:
+				log.Printf("🛠️  %s ouvre l'administration", m.username)
+				m.state = stateAdmin
 			case ui.MenuQuit:
-				log.Printf("DEBUG: Quitting")
 				return m, tea.Quit
 			}
 		}
@@ -222,25 +659,38 @@ func (m *appModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *appModel) updateQuiz(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.subModel == nil {
-		log.Printf("DEBUG: Création quiz model avec %d questions pour %s", len(questions), m.username)
-		// Toutes les questions (pas de filtre par catégorie)
-		m.subModel = ui.NewQuizModel(m.username, questions, m.category)
-		log.Printf("DEBUG: Quiz model créé, initialisation...")
+		quizModel := m.newQuizModel()
+		m.subModel = quizModel
+		m.scoreSaved = false
 		return m, m.subModel.Init()
 	}
 
+	answered := len(m.progress.Answers)
+
 	var cmd tea.Cmd
 	m.subModel, cmd = m.subModel.Update(msg)
 
 	// Vérifier si le quiz est terminé
 	if quizModel, ok := m.subModel.(ui.QuizModel); ok {
-		if quizModel.IsDone() {
-			// Sauvegarder le score
+		// Enregistrer chaque réponse, pour reprendre la partie après une déconnexion
+		if len(quizModel.GetAnswers()) != answered || (quizModel.IsFinished() && m.progress.Status == models.AttemptInProgress) {
+			countAnswers(quizModel, answered)
+			m.saveProgress(quizModel)
+		}
+		// Sauvegarder le score dès l'écran de fin, pour y afficher les badges débloqués
+		if quizModel.IsFinished() && !m.scoreSaved {
+			m.scoreSaved = true
+			quizzesCompleted.WithLabelValues("solo").Inc()
 			score := quizModel.GetScore()
-			if err := db.SaveScore(score); err != nil {
+			if _, err := db.SaveAttempt(score, quizModel.GetAnswers()); err != nil {
 				log.Printf("Erreur sauvegarde score: %v", err)
+			} else {
+				updateAttemptRatings(score, quizModel.GetAnswers())
+				m.subModel = quizModel.SetUnlocked(unlockAchievements(score))
 			}
-			log.Printf("DEBUG: Score sauvegardé, retour au menu")
+		}
+		if quizModel.IsDone() {
+			m.progress = models.Progress{}
 			m.subModel = nil
 			m.state = stateMenu
 			return m, nil
@@ -252,14 +702,8 @@ func (m *appModel) updateQuiz(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *appModel) updateLeaderboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.subModel == nil {
-		scores, err := db.GetLeaderboard(m.category, 10)
-		if err != nil {
-			log.Printf("Erreur récupération leaderboard: %v", err)
-			scores = []models.Score{}
-		}
-
-		stats, _ := db.GetStats()
-		m.subModel = ui.NewLeaderboardModel(m.username, m.category, scores, stats)
+		games, players, _ := db.GetStats()
+		m.subModel = ui.NewLeaderboardModel(m.lang, m.styles, m.username, m.category, games, players, leaderboardSource{category: m.category})
 		return m.updateLeaderboard(msg)
 	}
 
@@ -278,17 +722,324 @@ func (m *appModel) updateLeaderboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m *appModel) updateRatingLeaderboard(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		categories, err := db.GetCategories()
+		if err != nil {
+			log.Printf("Erreur récupération catégories: %v", err)
+		}
+		m.subModel = ui.NewRatingLeaderboardModel(m.lang, m.styles, m.username, categories, ratingSource{})
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if ratingModel, ok := m.subModel.(ui.RatingLeaderboardModel); ok && ratingModel.IsDone() {
+		m.subModel = nil
+		m.state = stateMenu
+		return m, nil
+	}
+
+	return m, cmd
+}
+
+func (m *appModel) updateProfile(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		profile, err := storage.GetProfile(db, m.username, profileHistory)
+		if err != nil {
+			log.Printf("Erreur récupération profil: %v", err)
+		}
+		m.subModel = ui.NewProfileModel(m.lang, m.styles, profile, err)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if profileModel, ok := m.subModel.(ui.ProfileModel); ok && profileModel.IsDone() {
+		m.subModel = nil
+		m.state = stateMenu
+		return m, nil
+	}
+
+	return m, cmd
+}
+
+func (m *appModel) updateAdmin(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		// Double vérification: l'entrée n'est proposée qu'aux administrateurs
+		if !m.admin {
+			m.state = stateMenu
+			return m, nil
+		}
+		m.subModel = ui.NewAdminModel(m.lang, m.styles, db)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if adminModel, ok := m.subModel.(ui.AdminModel); ok && adminModel.IsDone() {
+		m.subModel = nil
+		m.state = stateMenu
+		return m, nil
+	}
+
+	return m, cmd
+}
+
+func (m *appModel) updateSettings(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		m.subModel = ui.NewSettingsModel(m.lang, m.styles, m.prefs)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if settingsModel, ok := m.subModel.(ui.SettingsModel); ok && settingsModel.IsDone() {
+		if settingsModel.IsSaved() {
+			m.prefs = settingsModel.GetPreferences()
+			if err := db.SavePreferences(m.prefs); err != nil {
+				log.Printf("Erreur sauvegarde préférences: %v", err)
+			}
+			if lang, ok := i18n.Parse(m.prefs.Lang); ok {
+				m.lang = lang
+			}
+			m.styles = settingsModel.GetStyles()
+		}
+		m.subModel = nil
+		m.state = stateMenu
+		return m, m.mouseCmd()
+	}
+
+	return m, cmd
+}
+
+// handleLobby traite les messages du lobby de duel selon l'état courant
+func (m *appModel) handleLobby(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case lobby.PlayersChangedMsg:
+		if lobbyModel, ok := m.subModel.(ui.DuelLobbyModel); ok {
+			m.subModel = lobbyModel.SetPlayers(players.Available(m.id))
+		}
+
+	case lobby.InviteMsg:
+		// Le lobby n'envoie de défi qu'aux joueurs présents sur le menu
+		if m.state != stateMenu {
+			players.Respond(m.id, false)
+			return m, nil
+		}
+		players.SetAvailable(m.id, false)
+		m.opponent = msg.From
+		m.subModel = ui.NewDuelInviteModel(m.lang, m.styles, m.username, msg.From)
+		m.state = stateDuelInvite
+
+	case lobby.DeclinedMsg:
+		if m.state == stateDuelWait || m.state == stateDuelInvite {
+			log.Printf("Duel %s/%s annulé par %s", m.username, m.opponent, msg.By)
+			m.subModel = nil
+			m.state = stateMenu
+		}
+
+	case lobby.StartMsg:
+		m.opponent = msg.Opponent
+		m.duelID = msg.DuelID
+		m.duelSeed = msg.Seed
+		m.subModel = nil
+		m.state = stateDuel
+		return m.updateDuel(nil)
+
+	case lobby.ProgressMsg:
+		if quizModel, ok := m.subModel.(ui.QuizModel); ok && m.state == stateDuel && msg.DuelID == m.duelID {
+			m.subModel = quizModel.SetOpponentProgress(msg.Answered, msg.Score)
+		}
+
+	case lobby.ResultMsg:
+		if quizModel, ok := m.subModel.(ui.QuizModel); ok && m.state == stateDuel && msg.Result.DuelID == m.duelID {
+			m.subModel = quizModel.SetDuelResult(msg.Result)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *appModel) updateDuelLobby(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		record, err := db.GetDuelRecord(m.username)
+		if err != nil {
+			log.Printf("Erreur récupération bilan duels: %v", err)
+		}
+		m.subModel = ui.NewDuelLobbyModel(m.lang, m.styles, m.username, players.Available(m.id), record)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if lobbyModel, ok := m.subModel.(ui.DuelLobbyModel); ok && lobbyModel.IsDone() {
+		m.subModel = nil
+		target, ok := lobbyModel.GetSelected()
+		if !ok {
+			m.state = stateMenu
+			return m, nil
+		}
+		if err := players.Challenge(m.id, target.ID); err != nil {
+			log.Printf("Défi %s -> %s impossible: %v", m.username, target.Username, err)
+			m.state = stateDuelLobby
+			return m.updateDuelLobby(nil)
+		}
+		m.opponent = target.Username
+		m.subModel = ui.NewDuelWaitModel(m.lang, m.styles, m.username, target.Username)
+		m.state = stateDuelWait
+	}
+
+	return m, cmd
+}
+
+func (m *appModel) updateDuelPrompt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		m.state = stateMenu
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if promptModel, ok := m.subModel.(ui.DuelPromptModel); ok && promptModel.IsDone() {
+		if m.state == stateDuelInvite {
+			players.Respond(m.id, promptModel.IsAccepted())
+			if promptModel.IsAccepted() {
+				// Le lobby envoie StartMsg aux deux joueurs
+				return m, cmd
+			}
+		} else {
+			players.Cancel(m.id)
+		}
+		m.subModel = nil
+		m.state = stateMenu
+		return m, nil
+	}
+
+	return m, cmd
+}
+
+func (m *appModel) updateDuel(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		m.subModel = ui.NewDuelQuizModel(m.lang, m.styles, m.username, loadQuestions(), "Duel", m.duelSeed, duelQuestionCount, m.opponent).
+			SetConfirm(m.prefs.ConfirmAnswers)
+		quizzesStarted.WithLabelValues("duel").Inc()
+		return m, m.subModel.Init()
+	}
+
+	quizModel, ok := m.subModel.(ui.QuizModel)
+	if !ok {
+		return m, nil
+	}
+	answered, score, finished := quizModel.Answered(), quizModel.CurrentScore(), quizModel.IsFinished()
+	answers := len(quizModel.GetAnswers())
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if quizModel, ok := m.subModel.(ui.QuizModel); ok {
+		countAnswers(quizModel, answers)
+		if quizModel.IsFinished() && !finished {
+			quizzesCompleted.WithLabelValues("duel").Inc()
+		}
+		// Transmettre la progression à l'adversaire
+		if quizModel.Answered() != answered || quizModel.CurrentScore() != score {
+			players.Report(m.id, quizModel.Answered(), quizModel.CurrentScore(), quizModel.Total())
+		}
+		if quizModel.IsDone() {
+			m.subModel = nil
+			m.state = stateMenu
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+// leaderboardSource charge les pages du classement d'une catégorie depuis la base
+type leaderboardSource struct {
+	category string
+}
+
+func (s leaderboardSource) Page(period models.Period, search string, offset, limit int) ([]models.Score, int, error) {
+	scores, total, err := db.GetLeaderboardPage(s.category, period, search, offset, limit)
+	if err != nil {
+		log.Printf("Erreur récupération leaderboard: %v", err)
+	}
+	return scores, total, err
+}
+
+func (s leaderboardSource) Achievements(usernames []string) (map[string][]string, error) {
+	codes, err := db.GetAchievementCodes(usernames)
+	if err != nil {
+		log.Printf("Erreur récupération badges: %v", err)
+	}
+	return codes, err
+}
+
+// ratingSource charge le classement par niveau d'une catégorie depuis la base
+type ratingSource struct{}
+
+func (ratingSource) Leaderboard(category string, limit int) ([]models.Rating, error) {
+	ratings, err := db.GetRatingLeaderboard(category, limit)
+	if err != nil {
+		log.Printf("Erreur récupération classement par niveau: %v", err)
+	}
+	return ratings, err
+}
+
+func (ratingSource) Rating(username, category string) (models.Rating, error) {
+	r, err := db.GetRating(username, category)
+	if err != nil {
+		log.Printf("Erreur récupération niveau: %v", err)
+	}
+	return r, err
+}
+
+func (ratingSource) History(username, category string, limit int) ([]models.RatingChange, error) {
+	changes, err := db.GetRatingHistory(username, category, limit)
+	if err != nil {
+		log.Printf("Erreur récupération historique niveau: %v", err)
+	}
+	return changes, err
+}
+
+func (s leaderboardSource) UserRank(period models.Period, username string) (models.Score, bool, error) {
+	score, ok, err := db.GetUserRank(s.category, period, username)
+	if err != nil {
+		log.Printf("Erreur récupération rang: %v", err)
+	}
+	return score, ok, err
+}
+
 func (m *appModel) View() string {
+	view := m.viewport.View(m.screen(), m.bodyHeight(), m.lang, m.styles)
+	if banner := m.banner(); banner != "" {
+		return banner + "\n" + view
+	}
+	return view
+}
+
+// screen rend l'écran courant en entier, avant découpage par le viewport
+func (m *appModel) screen() string {
+	if m.closing != "" || m.idleWarning {
+		return m.sessionScreen()
+	}
 	if m.subModel != nil {
 		return m.subModel.View()
 	}
 
 	// Vue par défaut
-	style := lipgloss.NewStyle().
-		Padding(2).
-		Foreground(lipgloss.Color("#00ff9f"))
+	style := m.styles.Color(m.styles.Theme.Success).
+		Padding(2)
 
-	return style.Render("Chargement...")
+	return style.Render(m.lang.T("common.loading"))
 }
 
 // Questions par défaut pour démarrer
@@ -300,6 +1051,10 @@ func getDefaultQuestions() []models.Question {
 			Text:     "Quel protocole est utilisé pour sécuriser HTTP ?",
 			Options:  []string{"SSL/TLS", "FTP", "SMTP", "DNS"},
 			Answer:   0,
+			Lang:     "fr",
+			Translations: map[string]models.Translation{
+				"en": {Text: "Which protocol is used to secure HTTP?"},
+			},
 		},
 		{
 			ID:       2,
@@ -307,6 +1062,10 @@ func getDefaultQuestions() []models.Question {
 			Text:     "Qu'est-ce que AES ?",
 			Options:  []string{"Un hash", "Un chiffrement symétrique", "Un chiffrement asymétrique", "Un protocole réseau"},
 			Answer:   1,
+			Lang:     "fr",
+			Translations: map[string]models.Translation{
+				"en": {Text: "What is AES?", Options: []string{"A hash", "A symmetric cipher", "An asymmetric cipher", "A network protocol"}},
+			},
 		},
 		{
 			ID:       3,
@@ -314,6 +1073,10 @@ func getDefaultQuestions() []models.Question {
 			Text:     "Quel port utilise SSH par défaut ?",
 			Options:  []string{"21", "22", "23", "25"},
 			Answer:   1,
+			Lang:     "fr",
+			Translations: map[string]models.Translation{
+				"en": {Text: "Which port does SSH use by default?"},
+			},
 		},
 		{
 			ID:       4,
@@ -321,6 +1084,10 @@ func getDefaultQuestions() []models.Question {
 			Text:     "Qu'est-ce qu'une attaque XSS ?",
 			Options:  []string{"Cross-Site Scripting", "Cross-Site Security", "eXtreme Site Security", "eXternal Script Source"},
 			Answer:   0,
+			Lang:     "fr",
+			Translations: map[string]models.Translation{
+				"en": {Text: "What is an XSS attack?"},
+			},
 		},
 		{
 			ID:       5,
@@ -328,6 +1095,10 @@ func getDefaultQuestions() []models.Question {
 			Text:     "Quelle est la taille d'un hash SHA-256 en bits ?",
 			Options:  []string{"128", "192", "256", "512"},
 			Answer:   2,
+			Lang:     "fr",
+			Translations: map[string]models.Translation{
+				"en": {Text: "What is the size of a SHA-256 hash in bits?"},
+			},
 		},
 	}
 }
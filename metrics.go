@@ -0,0 +1,144 @@
+package main
+
+import (
+	"quizz-ssh/limiter"
+	"quizz-ssh/ui"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// defaultMetricsPath est la route des métriques Prometheus, à défaut de METRICS_PATH
+const defaultMetricsPath = "/metrics"
+
+// Métriques du serveur SSH; celles de la base et de l'API sont déclarées dans
+// leurs paquets. mode vaut "solo" ou "duel".
+var (
+	quizzesStarted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_ssh_quizzes_started_total",
+		Help: "Parties commencées, par mode.",
+	}, []string{"mode"})
+	quizzesResumed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "quiz_ssh_quizzes_resumed_total",
+		Help: "Parties solo interrompues puis reprises.",
+	})
+	quizzesCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_ssh_quizzes_completed_total",
+		Help: "Parties terminées (toutes les questions répondues), par mode.",
+	}, []string{"mode"})
+	quizzesAbandoned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_ssh_quizzes_abandoned_total",
+		Help: "Parties abandonnées: partie solo non reprise, duel quitté en cours.",
+	}, []string{"mode"})
+
+	// Le taux de bonnes réponses d'une catégorie se calcule à partir de result
+	answersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_ssh_answers_total",
+		Help: "Réponses données, par catégorie et résultat (correct, wrong).",
+	}, []string{"category", "result"})
+
+	questionBankLoads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "quiz_ssh_question_bank_loads_total",
+		Help: "Chargements de la banque de questions, par résultat (ok, error, fallback).",
+	}, []string{"result"})
+	questionBankLastLoad = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "quiz_ssh_question_bank_last_load_timestamp_seconds",
+		Help: "Date du dernier chargement de la banque de questions.",
+	})
+	questionBankLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "quiz_ssh_question_bank_last_load_success",
+		Help: "1 si le dernier chargement a fourni des questions de la banque, 0 en cas d'erreur ou de repli sur les questions par défaut.",
+	})
+	questionBankQuestions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "quiz_ssh_question_bank_questions",
+		Help: "Questions actives au dernier chargement de la banque.",
+	})
+)
+
+// rejectReasons nomme les motifs de refus du limiteur dans les métriques
+var rejectReasons = []struct {
+	reason limiter.Reason
+	label  string
+}{
+	{limiter.RateLimited, "rate_limited"},
+	{limiter.Banned, "banned"},
+	{limiter.TooManyIP, "too_many_ip"},
+	{limiter.TooManyIdentity, "too_many_identity"},
+	{limiter.Full, "full"},
+}
+
+// sessionCollector calcule à la lecture les métriques des sessions ouvertes
+// et des refus du limiteur
+type sessionCollector struct {
+	active   *prometheus.Desc
+	byState  *prometheus.Desc
+	rejected *prometheus.Desc
+}
+
+func newSessionCollector() sessionCollector {
+	return sessionCollector{
+		active: prometheus.NewDesc("quiz_ssh_sessions_active",
+			"Sessions SSH ouvertes.", nil, nil),
+		byState: prometheus.NewDesc("quiz_ssh_sessions_by_state",
+			"Sessions SSH ouvertes, par écran.", []string{"state"}, nil),
+		rejected: prometheus.NewDesc("quiz_ssh_connections_rejected_total",
+			"Connexions et sessions SSH refusées par le limiteur, par motif.", []string{"reason"}, nil),
+	}
+}
+
+func (c sessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.active
+	ch <- c.byState
+	ch <- c.rejected
+}
+
+func (c sessionCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(sessions.Count()))
+	counts := sessions.CountByState()
+	for state, name := range appStates {
+		ch <- prometheus.MustNewConstMetric(c.byState, prometheus.GaugeValue, float64(counts[appState(state)]), name)
+	}
+	stats := limits.Stats()
+	for _, r := range rejectReasons {
+		ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(stats.Rejected[r.reason]), r.label)
+	}
+}
+
+// registerMetrics déclare les métriques calculées à la lecture, à partir des
+// sessions ouvertes et des compteurs du limiteur
+func registerMetrics() {
+	prometheus.MustRegister(newSessionCollector())
+}
+
+// countAnswers compte, par catégorie, les réponses données à partir de la from-ième
+func countAnswers(quizModel ui.QuizModel, from int) {
+	answers := quizModel.GetAnswers()
+	for i := from; i < len(answers); i++ {
+		result := "wrong"
+		if answers[i].Correct {
+			result = "correct"
+		}
+		answersTotal.WithLabelValues(quizModel.Question(i).Category, result).Inc()
+	}
+}
+
+// observeQuestionBank enregistre le résultat d'un chargement de la banque:
+// n questions actives, ou le repli sur les questions par défaut
+func observeQuestionBank(n int, err error) {
+	result := "ok"
+	switch {
+	case err != nil:
+		result = "error"
+	case n == 0:
+		result = "fallback"
+	}
+	questionBankLoads.WithLabelValues(result).Inc()
+	questionBankLastLoad.Set(float64(time.Now().Unix()))
+	questionBankQuestions.Set(float64(n))
+	if result == "ok" {
+		questionBankLastSuccess.Set(1)
+	} else {
+		questionBankLastSuccess.Set(0)
+	}
+}
@@ -1,29 +1,341 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"quizz-ssh/i18n"
+	"strings"
+	"time"
+)
 
 // Question représente une question du quiz
 type Question struct {
 	ID              int      `json:"id"`
+	Revision        int      `json:"revision,omitempty"` // Version du contenu en base, incrémentée à chaque modification
 	Category        string   `json:"category"`
 	Text            string   `json:"text"`
 	Options         []string `json:"options"`
-	Answer          int      `json:"answer"`          // Index de la bonne réponse (original)
-	ShuffledOptions []string `json:"-"`               // Options mélangées (pas sauvegardé en JSON)
-	ShuffledAnswer  int      `json:"-"`               // Index de la bonne réponse après shuffle
+	Answer          int      `json:"answer"`                // Index de la bonne réponse (original)
+	Explanation     string   `json:"explanation,omitempty"` // Affichée après la réponse
+	Difficulty      int      `json:"difficulty,omitempty"`  // 1 (facile) à 5 (difficile), 0 = non renseignée
+	Disabled        bool     `json:"disabled,omitempty"`    // Retirée des quiz par un administrateur
+	ShuffledOptions []string `json:"-"`                     // Options mélangées (pas sauvegardé en JSON)
+	ShuffledAnswer  int      `json:"-"`                     // Index de la bonne réponse après shuffle
+	ShuffleOrder    []int    `json:"-"`                     // Index original de chaque option mélangée
+
+	Lang         string                 `json:"lang,omitempty"`         // Langue du texte d'origine ("fr", "en")
+	Translations map[string]Translation `json:"translations,omitempty"` // Traductions par code de langue
+}
+
+// Translation est le contenu d'une question dans une autre langue.
+// Les options suivent l'ordre d'origine: la bonne réponse garde son index.
+type Translation struct {
+	Text        string   `json:"text"`
+	Options     []string `json:"options"`
+	Explanation string   `json:"explanation,omitempty"`
+}
+
+// Localize retourne la question traduite dans lang, à défaut dans fallback,
+// à défaut dans sa langue d'origine. Un champ non traduit garde le texte d'origine.
+func (q Question) Localize(lang, fallback string) Question {
+	for _, l := range []string{lang, fallback} {
+		if l == q.Lang {
+			return q
+		}
+		t, ok := q.Translations[l]
+		if !ok {
+			continue
+		}
+		if t.Text != "" {
+			q.Text = t.Text
+		}
+		if len(t.Options) == len(q.Options) {
+			q.Options = t.Options
+		}
+		if t.Explanation != "" {
+			q.Explanation = t.Explanation
+		}
+		return q
+	}
+	return q
+}
+
+// Validate vérifie qu'une question peut être enregistrée dans la banque
+func (q Question) Validate() error {
+	if strings.TrimSpace(q.Category) == "" {
+		return errors.New("la catégorie est obligatoire")
+	}
+	if strings.TrimSpace(q.Text) == "" {
+		return errors.New("l'énoncé est obligatoire")
+	}
+	if len(q.Options) < 2 {
+		return errors.New("il faut au moins deux réponses possibles")
+	}
+	for i, opt := range q.Options {
+		if strings.TrimSpace(opt) == "" {
+			return fmt.Errorf("la réponse %d est vide", i+1)
+		}
+	}
+	if q.Answer < 0 || q.Answer >= len(q.Options) {
+		return fmt.Errorf("la bonne réponse doit être comprise entre 1 et %d", len(q.Options))
+	}
+	if q.Difficulty < 0 || q.Difficulty > 5 {
+		return errors.New("la difficulté doit être comprise entre 0 et 5")
+	}
+	for lang, t := range q.Translations {
+		if strings.TrimSpace(t.Text) == "" {
+			return fmt.Errorf("traduction %q: l'énoncé est obligatoire", lang)
+		}
+		if len(t.Options) != 0 && len(t.Options) != len(q.Options) {
+			return fmt.Errorf("traduction %q: il faut %d réponses", lang, len(q.Options))
+		}
+	}
+	return nil
+}
+
+// Truncate raccourcit un texte à max caractères, terminé par "…" s'il est coupé
+func Truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// Answer représente la réponse d'un joueur à une question
+type Answer struct {
+	QuestionID int           `json:"question_id"`
+	Revision   int           `json:"revision"` // Version de la question posée
+	Chosen     int           `json:"chosen"`   // Index de l'option choisie (ordre original)
+	Correct    bool          `json:"correct"`
+	Duration   time.Duration `json:"duration"`
 }
 
 // Score représente le score d'un utilisateur
 type Score struct {
+	ID        int           `json:"id"`
+	Username  string        `json:"username"`
+	Category  string        `json:"category"`
+	Score     int           `json:"score"`
+	Total     int           `json:"total"`
+	Duration  time.Duration `json:"duration"` // Temps total passé à répondre
+	Rank      int           `json:"rank"`     // Position au classement (partagée en cas d'égalité)
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// FormatDuration affiche une durée en minutes et secondes ("2:05")
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// QuizData contient toutes les questions
+type QuizData struct {
+	Questions []Question `json:"questions"`
+}
+
+// Duel représente le résultat d'un duel entre deux joueurs
+type Duel struct {
 	ID        int       `json:"id"`
+	Player1   string    `json:"player1"`
+	Player2   string    `json:"player2"`
+	Score1    int       `json:"score1"`
+	Score2    int       `json:"score2"`
+	Total     int       `json:"total"`
+	Winner    string    `json:"winner"` // vide en cas d'égalité
+	Forfeit   bool      `json:"forfeit"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuelRecord est le bilan des duels d'un joueur
+type DuelRecord struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+// Rating représente le niveau Elo d'un joueur, global ou pour une catégorie
+type Rating struct {
 	Username  string    `json:"username"`
 	Category  string    `json:"category"`
-	Score     int       `json:"score"`
-	Total     int       `json:"total"`
+	Rating    float64   `json:"rating"`
+	Games     int       `json:"games"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RatingChange représente une évolution du niveau d'un joueur
+type RatingChange struct {
+	Username  string    `json:"username"`
+	Category  string    `json:"category"`
+	Rating    float64   `json:"rating"`
+	Delta     float64   `json:"delta"`
+	Source    string    `json:"source"` // "quiz" ou "duel"
 	CreatedAt time.Time `json:"created_at"`
 }
 
-// QuizData contient toutes les questions
-type QuizData struct {
-	Questions []Question `json:"questions"`
+// Period est la fenêtre de temps couverte par un classement
+type Period int
+
+const (
+	PeriodAll Period = iota
+	PeriodDay
+	PeriodWeek
+	PeriodMonth
+)
+
+// periodNames nomme chaque période dans les URL de l'API et de la page web
+var periodNames = map[Period]string{
+	PeriodAll:   "all",
+	PeriodDay:   "day",
+	PeriodWeek:  "week",
+	PeriodMonth: "month",
+}
+
+// Name retourne le nom de la période dans les URL ("week")
+func (p Period) Name() string {
+	return periodNames[p]
+}
+
+// Label retourne le nom de la période affiché aux joueurs, dans leur langue
+func (p Period) Label(lang i18n.Lang) string {
+	name, ok := periodNames[p]
+	if !ok {
+		name = periodNames[PeriodAll]
+	}
+	return lang.T("period." + name)
+}
+
+// ParsePeriod reconnaît le nom d'une période; un nom vide désigne PeriodAll
+func ParsePeriod(name string) (Period, bool) {
+	if name == "" {
+		return PeriodAll, true
+	}
+	for p, n := range periodNames {
+		if n == name {
+			return p, true
+		}
+	}
+	return PeriodAll, false
+}
+
+// Start retourne le début de la période contenant now, dans le fuseau de now
+// (les semaines commencent le lundi). Retourne le temps zéro pour PeriodAll.
+func (p Period) Start(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch p {
+	case PeriodDay:
+		return day
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case PeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+// UserStats agrège l'activité d'un joueur
+type UserStats struct {
+	Attempts    int           `json:"attempts"`
+	Correct     int           `json:"correct"`
+	Questions   int           `json:"questions"`
+	TotalTime   time.Duration `json:"total_time"`
+	FirstPlayed time.Time     `json:"first_played"`
+	LastPlayed  time.Time     `json:"last_played"`
+}
+
+// SuccessRate retourne le taux de bonnes réponses en pourcentage
+func (s UserStats) SuccessRate() float64 {
+	if s.Questions == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Questions) * 100
+}
+
+// Profile regroupe tout ce qu'affiche l'écran de profil d'un joueur
+type Profile struct {
+	Username string     `json:"username"`
+	Stats    UserStats  `json:"stats"`
+	Best     []Score    `json:"best"`    // Meilleure partie par catégorie
+	History  []Score    `json:"history"` // Dernières parties, de la plus récente à la plus ancienne
+	Duels    DuelRecord `json:"duels"`
+	Rating   Rating     `json:"rating"`
+
+	Achievements []UserAchievement `json:"achievements"`
+}
+
+// UserAchievement représente un badge débloqué par un joueur
+type UserAchievement struct {
+	Username   string    `json:"username"`
+	Code       string    `json:"code"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+// Report est le signalement d'une question par un joueur
+type Report struct {
+	ID         int       `json:"id"`
+	QuestionID int       `json:"question_id"`
+	Revision   int       `json:"revision"` // Version de la question signalée
+	Username   string    `json:"username"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	Resolution string    `json:"resolution,omitempty"` // Vide tant que le signalement est en attente
+	ResolvedAt time.Time `json:"resolved_at,omitempty"`
+}
+
+// RecordedAnswer est une réponse enregistrée, avec le joueur qui l'a donnée
+type RecordedAnswer struct {
+	Answer
+	Username string `json:"username"`
+}
+
+// Preferences regroupe les réglages d'un joueur, conservés d'une session à l'autre
+type Preferences struct {
+	Username       string    `json:"username"`
+	Lang           string    `json:"lang"`            // Vide: langue détectée ou langue par défaut
+	Theme          string    `json:"theme"`           // Vide: thème déduit du terminal
+	ConfirmAnswers bool      `json:"confirm_answers"` // Demander confirmation avant de valider une réponse
+	Mouse          bool      `json:"mouse"`           // Clic sur les options (empêche la sélection de texte du terminal)
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AttemptStatus est l'état d'une partie enregistrée au fil des réponses
+type AttemptStatus string
+
+const (
+	AttemptInProgress AttemptStatus = "in_progress" // En cours, ou interrompue et encore reprenable
+	AttemptFinished   AttemptStatus = "finished"
+	AttemptAbandoned  AttemptStatus = "abandoned" // Interrompue et pas reprise à temps
+)
+
+// Progress est l'état d'une partie solo, enregistré après chaque réponse
+// pour pouvoir la reprendre après une déconnexion
+type Progress struct {
+	ID                int           `json:"id"`
+	Identity          string        `json:"identity"` // Empreinte de la clé SSH du joueur, vide sans clé
+	Username          string        `json:"username"`
+	Category          string        `json:"category"`
+	Seed              int64         `json:"seed"`               // Graine du mélange des options
+	QuestionIDs       []int         `json:"question_ids"`       // Questions posées, dans l'ordre
+	QuestionRevisions []int         `json:"question_revisions"` // Version posée de chacune de ces questions
+	CurrentIndex      int           `json:"current_index"`      // Prochaine question à poser
+	Answers           []Answer      `json:"answers"`            // Réponses déjà données, dans l'ordre
+	Status            AttemptStatus `json:"status"`
+	StartedAt         time.Time     `json:"started_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+// Score retourne le nombre de bonnes réponses déjà données
+func (p Progress) Score() int {
+	score := 0
+	for _, a := range p.Answers {
+		if a.Correct {
+			score++
+		}
+	}
+	return score
 }
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodStart(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+	at := func(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+		return time.Date(year, month, day, hour, 30, 0, 0, loc)
+	}
+	tests := []struct {
+		name   string
+		period Period
+		now    time.Time
+		want   time.Time
+	}{
+		{"jour", PeriodDay, at(2024, 3, 13, 15, time.UTC), time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{"semaine un mercredi", PeriodWeek, at(2024, 3, 13, 15, time.UTC), time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"semaine un lundi", PeriodWeek, at(2024, 3, 11, 0, time.UTC), time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"semaine un dimanche soir", PeriodWeek, at(2024, 3, 17, 23, time.UTC), time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"semaine à cheval sur deux mois", PeriodWeek, at(2024, 3, 2, 12, time.UTC), time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{"semaine à cheval sur deux années", PeriodWeek, at(2025, 1, 1, 12, time.UTC), time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+		// Dimanche 23h30 UTC est déjà lundi à Paris: la semaine dépend du fuseau de now
+		{"semaine dans le fuseau de now", PeriodWeek, at(2024, 3, 17, 23, time.UTC).In(paris), time.Date(2024, 3, 18, 0, 0, 0, 0, paris)},
+		{"mois", PeriodMonth, at(2024, 2, 29, 23, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"depuis toujours", PeriodAll, at(2024, 3, 13, 15, time.UTC), time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Start(tt.now); !got.Equal(tt.want) {
+				t.Errorf("Start(%v) = %v, attendu %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"log"
+	"quizz-ssh/i18n"
+	"quizz-ssh/ui"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/muesli/termenv"
+)
+
+// sessionEnviron expose à termenv les variables d'environnement du client SSH
+type sessionEnviron []string
+
+func (e sessionEnviron) Environ() []string {
+	return e
+}
+
+func (e sessionEnviron) Getenv(key string) string {
+	for _, kv := range e {
+		if k, value, ok := strings.Cut(kv, "="); ok && k == key {
+			return value
+		}
+	}
+	return ""
+}
+
+// sessionLang déduit la langue de l'interface des variables de locale transmises
+// par le client SSH (LC_ALL, LC_MESSAGES, LANG), à défaut la langue par défaut
+func sessionLang(s ssh.Session) i18n.Lang {
+	env := sessionEnviron(s.Environ())
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang, ok := i18n.Parse(env.Getenv(key)); ok {
+			return lang
+		}
+	}
+	return i18n.Default
+}
+
+// sessionStyles prépare le rendu pour le terminal du client, d'après son TERM,
+// COLORTERM et NO_COLOR. Sans préférence enregistrée, le thème suit le client:
+// monochrome si NO_COLOR est défini, clair si COLORFGBG annonce un fond clair.
+// Le terminal n'est pas interrogé: un client qui ne répond pas perdrait ses
+// premières frappes.
+func sessionStyles(s ssh.Session) *ui.Styles {
+	pty, _, _ := s.Pty()
+	env := sessionEnviron(append(s.Environ(), "TERM="+pty.Term))
+	r := lipgloss.NewRenderer(s, termenv.WithEnvironment(env), termenv.WithUnsafe(), termenv.WithColorCache(true))
+
+	theme := ui.DarkTheme
+	switch {
+	case r.Output().EnvNoColor():
+		theme = ui.MonochromeTheme
+	case lightBackground(env.Getenv("COLORFGBG")):
+		theme = ui.LightTheme
+	}
+	// NO_COLOR retire les couleurs, pas le gras ni la vidéo inverse dont le thème
+	// monochrome a besoin: le renderer garde les capacités réelles du terminal
+	r.SetColorProfile(r.Output().ColorProfile())
+	return ui.NewStyles(r, theme)
+}
+
+// lightBackground interprète COLORFGBG ("15;0", "0;default;15"): le dernier
+// champ est la couleur ANSI du fond, claire pour le gris (7) et les couleurs vives
+func lightBackground(colorfgbg string) bool {
+	fields := strings.Split(colorfgbg, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	return err == nil && (bg == 7 || bg >= 9)
+}
+
+// applyPreferences applique la langue et le thème enregistrés par le joueur,
+// à défaut ceux déduits de sa session
+func (m *appModel) applyPreferences() {
+	prefs, err := db.GetPreferences(m.username)
+	if err != nil {
+		log.Printf("Erreur récupération préférences: %v", err)
+	}
+	prefs.Username = m.username
+	m.prefs = prefs
+	if lang, ok := i18n.Parse(prefs.Lang); ok {
+		m.lang = lang
+	}
+	if theme, ok := ui.ThemeByName(prefs.Theme); ok {
+		m.styles = m.styles.WithTheme(theme)
+	}
+}
+
+// mouseCmd active le suivi de la souris si le joueur l'a demandé: sinon le
+// terminal garde la sélection de texte et le défilement habituels
+func (m *appModel) mouseCmd() tea.Cmd {
+	if m.prefs.Mouse {
+		return tea.EnableMouseCellMotion
+	}
+	return tea.DisableMouse
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"quizz-ssh/models"
+	"quizz-ssh/storage"
+	"quizz-ssh/ui"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+)
+
+// defaultResumeWindow est le délai pendant lequel une partie interrompue peut
+// être reprise, à défaut de RESUME_WINDOW (0 désactive la reprise)
+const defaultResumeWindow = 30 * time.Minute
+
+var resumeWindow = defaultResumeWindow
+
+// sweepProgress enregistre régulièrement comme abandonnées les parties dont le
+// délai de reprise est écoulé, même si le joueur ne revient pas; celles des
+// sessions ouvertes sont épargnées, le joueur réfléchit peut-être encore
+func sweepProgress(interval time.Duration) {
+	for {
+		if n, err := db.AbandonStaleProgress(time.Now().Add(-resumeWindow), sessions.LiveProgress()); err != nil {
+			log.Printf("Erreur abandon parties interrompues: %v", err)
+		} else if n > 0 {
+			quizzesAbandoned.WithLabelValues("solo").Add(float64(n))
+			log.Printf("⏹️  %d partie(s) interrompue(s) enregistrée(s) comme abandonnée(s)", n)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// abandonUnresumable enregistre comme abandonnée, à la fin d'une session, la
+// partie qu'elle laisse en cours si personne ne pourra la reprendre: reprise
+// désactivée ou joueur sans clé SSH
+func abandonUnresumable(s ssh.Session, progressID int) {
+	if progressID == 0 || (resumeWindow > 0 && identity(s) != "") {
+		return
+	}
+	err := db.SetProgressStatus(progressID, models.AttemptAbandoned)
+	switch {
+	case errors.Is(err, storage.ErrProgressAbandoned):
+		// Partie terminée ou déjà abandonnée
+	case err != nil:
+		log.Printf("Erreur abandon partie: %v", err)
+	default:
+		quizzesAbandoned.WithLabelValues("solo").Inc()
+		log.Printf("⏹️  Partie #%d abandonnée à la déconnexion: elle ne peut pas être reprise", progressID)
+	}
+}
+
+// openProgress cherche une partie interrompue que le joueur peut reprendre;
+// celle dont le délai de reprise est écoulé, ou qui n'avait pas commencé,
+// est enregistrée comme abandonnée. Seule la clé SSH qui l'a commencée peut
+// la reprendre: sans clé, le pseudo seul ne prouve rien
+func (m *appModel) openProgress() bool {
+	id := identity(m.session)
+	if id == "" {
+		return false
+	}
+	p, ok, err := db.GetOpenProgress(id, m.username)
+	if err != nil {
+		log.Printf("Erreur récupération partie en cours: %v", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if len(p.Answers) == 0 || time.Since(p.UpdatedAt) > resumeWindow {
+		m.progress = p
+		m.abandonProgress()
+		return false
+	}
+	m.progress = p
+	return true
+}
+
+// abandonProgress enregistre la partie en cours comme abandonnée
+func (m *appModel) abandonProgress() {
+	if m.progress.ID == 0 {
+		return
+	}
+	err := db.SetProgressStatus(m.progress.ID, models.AttemptAbandoned)
+	switch {
+	case errors.Is(err, storage.ErrProgressAbandoned):
+		// Déjà abandonnée par le balayage: déjà comptée
+	case err != nil:
+		log.Printf("Erreur abandon partie: %v", err)
+	default:
+		quizzesAbandoned.WithLabelValues("solo").Inc()
+		log.Printf("⏹️  Partie #%d de %s abandonnée (%d/%d réponses)",
+			m.progress.ID, m.username, len(m.progress.Answers), len(m.progress.QuestionIDs))
+	}
+	m.progress = models.Progress{}
+}
+
+// saveProgress enregistre l'état de la partie après une réponse
+func (m *appModel) saveProgress(quizModel ui.QuizModel) {
+	m.progress.Answers = quizModel.GetAnswers()
+	m.progress.CurrentIndex = len(m.progress.Answers)
+	if m.progress.Status == models.AttemptAbandoned {
+		return
+	}
+	if quizModel.IsFinished() {
+		m.progress.Status = models.AttemptFinished
+	}
+	id, err := db.SaveProgress(m.progress)
+	if errors.Is(err, storage.ErrProgressAbandoned) {
+		// Abandonnée entre-temps: le joueur finit sa partie, qui ne sera plus reprenable
+		log.Printf("⏹️  Partie #%d de %s abandonnée entre-temps, elle ne pourra plus être reprise", m.progress.ID, m.username)
+		m.progress.Status = models.AttemptAbandoned
+		return
+	}
+	if err != nil {
+		log.Printf("Erreur sauvegarde partie en cours: %v", err)
+		return
+	}
+	m.progress.ID = id
+}
+
+// newQuizModel démarre une nouvelle partie, ou reconstruit la partie
+// interrompue que le joueur a choisi de reprendre
+func (m *appModel) newQuizModel() ui.QuizModel {
+	if m.progress.ID != 0 {
+		if questions, ok := progressQuestions(m.progress); ok {
+			log.Printf("▶️  %s reprend la partie #%d à la question %d/%d",
+				m.username, m.progress.ID, len(m.progress.Answers)+1, len(questions))
+			m.category = m.progress.Category
+			quizzesResumed.Inc()
+			return ui.NewQuizModel(m.lang, m.styles, m.username, questions, m.category, m.progress.Seed).
+				SetConfirm(m.prefs.ConfirmAnswers).
+				Restore(m.progress.Answers)
+		}
+		m.abandonProgress()
+	}
+
+	// Toutes les questions (pas de filtre par catégorie)
+	questions := loadQuestions()
+	m.progress = models.Progress{
+		Identity: identity(m.session),
+		Username: m.username,
+		Category: m.category,
+		Seed:     time.Now().UnixNano(),
+		Status:   models.AttemptInProgress,
+	}
+	for _, q := range questions {
+		m.progress.QuestionIDs = append(m.progress.QuestionIDs, q.ID)
+		m.progress.QuestionRevisions = append(m.progress.QuestionRevisions, q.Revision)
+	}
+	id, err := db.SaveProgress(m.progress)
+	if err != nil {
+		log.Printf("Erreur sauvegarde partie en cours: %v", err)
+	}
+	m.progress.ID = id
+	quizzesStarted.WithLabelValues("solo").Inc()
+
+	return ui.NewQuizModel(m.lang, m.styles, m.username, questions, m.category, m.progress.Seed).
+		SetConfirm(m.prefs.ConfirmAnswers)
+}
+
+// progressQuestions retrouve les questions d'une partie interrompue, dans leur
+// ordre et dans la version posée; ok vaut false si l'une d'elles a été
+// supprimée entre-temps
+func progressQuestions(p models.Progress) ([]models.Question, bool) {
+	if len(p.QuestionRevisions) != len(p.QuestionIDs) {
+		return nil, false
+	}
+	questions := make([]models.Question, 0, len(p.QuestionIDs))
+	for i, id := range p.QuestionIDs {
+		q, err := db.GetQuestionRevision(id, p.QuestionRevisions[i])
+		if err != nil {
+			log.Printf("Erreur récupération question #%d de la partie #%d: %v", id, p.ID, err)
+			return nil, false
+		}
+		questions = append(questions, q)
+	}
+	return questions, len(questions) > 0
+}
+
+func (m *appModel) updateResume(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.subModel == nil {
+		m.subModel = ui.NewResumeModel(m.lang, m.styles, m.progress)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.subModel, cmd = m.subModel.Update(msg)
+
+	if resumeModel, ok := m.subModel.(ui.ResumeModel); ok && resumeModel.IsDone() {
+		m.subModel = nil
+		if resumeModel.IsAccepted() {
+			m.state = stateQuiz
+			return m.updateQuiz(msg)
+		}
+		m.abandonProgress()
+		m.state = stateMenu
+		return m, nil
+	}
+
+	return m, cmd
+}
@@ -0,0 +1,70 @@
+package rating
+
+import "math"
+
+const (
+	// Initial est le niveau attribué à un nouveau joueur
+	Initial = 1000.0
+
+	// Facteurs K: un joueur est "provisoire" tant qu'il a peu de parties,
+	// son niveau bouge alors plus vite pour converger
+	kQuestion        = 8.0
+	kDuel            = 32.0
+	provisionalGames = 10
+	provisionalBoost = 2.0
+
+	// Écart de niveau entre deux crans de difficulté
+	difficultyStep    = 150.0
+	defaultDifficulty = 3
+)
+
+// Outcome est le résultat d'un joueur face à une question
+type Outcome struct {
+	Difficulty int
+	Correct    bool
+}
+
+// Expected retourne la probabilité que a l'emporte sur b
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// QuestionRating convertit une difficulté (1 à 5) en niveau Elo
+func QuestionRating(difficulty int) float64 {
+	if difficulty <= 0 {
+		difficulty = defaultDifficulty
+	}
+	return Initial + float64(difficulty-defaultDifficulty)*difficultyStep
+}
+
+func kFactor(base float64, games int) float64 {
+	if games < provisionalGames {
+		return base * provisionalBoost
+	}
+	return base
+}
+
+// Attempt calcule le nouveau niveau après une partie: chaque question est
+// un "match" contre la question, pondéré par sa difficulté
+func Attempt(current float64, games int, outcomes []Outcome) float64 {
+	k := kFactor(kQuestion, games)
+
+	var delta float64
+	for _, o := range outcomes {
+		actual := 0.0
+		if o.Correct {
+			actual = 1
+		}
+		delta += k * (actual - Expected(current, QuestionRating(o.Difficulty)))
+	}
+	return current + delta
+}
+
+// Duel calcule les nouveaux niveaux des deux joueurs; score vaut 1 si a
+// gagne, 0 s'il perd et 0.5 en cas d'égalité
+func Duel(a, b float64, gamesA, gamesB int, score float64) (float64, float64) {
+	expected := Expected(a, b)
+	newA := a + kFactor(kDuel, gamesA)*(score-expected)
+	newB := b + kFactor(kDuel, gamesB)*((1-score)-(1-expected))
+	return newA, newB
+}
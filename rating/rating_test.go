@@ -0,0 +1,123 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < epsilon
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b float64
+		want float64
+	}{
+		{"niveaux égaux", 1000, 1000, 0.5},
+		{"400 points d'avance", 1400, 1000, 10.0 / 11},
+		{"400 points de retard", 1000, 1400, 1.0 / 11},
+		{"800 points d'avance", 1800, 1000, 100.0 / 101},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Expected(tt.a, tt.b); !near(got, tt.want) {
+				t.Errorf("Expected(%v, %v) = %v, attendu %v", tt.a, tt.b, got, tt.want)
+			}
+			if sum := Expected(tt.a, tt.b) + Expected(tt.b, tt.a); !near(sum, 1) {
+				t.Errorf("Expected(a, b) + Expected(b, a) = %v, attendu 1", sum)
+			}
+		})
+	}
+}
+
+func TestQuestionRating(t *testing.T) {
+	tests := []struct {
+		difficulty int
+		want       float64
+	}{
+		{0, 1000}, // non renseignée: difficulté moyenne
+		{1, 700},
+		{2, 850},
+		{3, 1000},
+		{4, 1150},
+		{5, 1300},
+	}
+	for _, tt := range tests {
+		if got := QuestionRating(tt.difficulty); got != tt.want {
+			t.Errorf("QuestionRating(%d) = %v, attendu %v", tt.difficulty, got, tt.want)
+		}
+	}
+}
+
+func TestKFactor(t *testing.T) {
+	tests := []struct {
+		base  float64
+		games int
+		want  float64
+	}{
+		{kDuel, 0, 64},
+		{kDuel, provisionalGames - 1, 64},
+		{kDuel, provisionalGames, 32},
+		{kQuestion, 3, 16},
+		{kQuestion, 50, 8},
+	}
+	for _, tt := range tests {
+		if got := kFactor(tt.base, tt.games); got != tt.want {
+			t.Errorf("kFactor(%v, %d) = %v, attendu %v", tt.base, tt.games, got, tt.want)
+		}
+	}
+}
+
+func TestAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  float64
+		games    int
+		outcomes []Outcome
+		want     float64
+	}{
+		{"aucune réponse", 1000, 20, nil, 1000},
+		{"bonne réponse, difficulté moyenne", 1000, 20, []Outcome{{3, true}}, 1004},
+		{"mauvaise réponse, difficulté moyenne", 1000, 20, []Outcome{{3, false}}, 996},
+		{"joueur provisoire", 1000, 0, []Outcome{{3, true}}, 1008},
+		{"une bonne et une mauvaise s'annulent", 1000, 20, []Outcome{{3, true}, {3, false}}, 1000},
+		{"question facile ratée", 1000, 20, []Outcome{{1, false}}, 1000 - 8*Expected(1000, 700)},
+		{"question difficile réussie", 1000, 20, []Outcome{{5, true}}, 1000 + 8*(1-Expected(1000, 1300))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Attempt(tt.current, tt.games, tt.outcomes); !near(got, tt.want) {
+				t.Errorf("Attempt() = %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuel(t *testing.T) {
+	tests := []struct {
+		name           string
+		a, b           float64
+		gamesA, gamesB int
+		score          float64
+		wantA, wantB   float64
+	}{
+		{"victoire à niveau égal", 1000, 1000, 20, 20, 1, 1016, 984},
+		{"défaite à niveau égal", 1000, 1000, 20, 20, 0, 984, 1016},
+		{"égalité à niveau égal", 1000, 1000, 20, 20, 0.5, 1000, 1000},
+		{"victoire d'un joueur provisoire", 1000, 1000, 0, 20, 1, 1032, 984},
+		{"victoire du favori", 1400, 1000, 20, 20, 1, 1400 + 32.0/11, 1000 - 32.0/11},
+		{"victoire de l'outsider", 1000, 1400, 20, 20, 1, 1000 + 32*10.0/11, 1400 - 32*10.0/11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotA, gotB := Duel(tt.a, tt.b, tt.gamesA, tt.gamesB, tt.score)
+			if !near(gotA, tt.wantA) || !near(gotB, tt.wantB) {
+				t.Errorf("Duel() = %v, %v, attendu %v, %v", gotA, gotB, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// registry suit les programmes des sessions ouvertes, pour leur envoyer des
+// messages à tous (arrêt du serveur, annonces), l'écran de chacune et sa
+// partie en cours
+type registry struct {
+	mu       sync.Mutex
+	programs map[string]*tea.Program
+	states   map[string]appState
+	progress map[string]int
+}
+
+var sessions = &registry{
+	programs: make(map[string]*tea.Program),
+	states:   make(map[string]appState),
+	progress: make(map[string]int),
+}
+
+// Add enregistre le programme d'une session
+func (r *registry) Add(id string, p *tea.Program) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.programs[id] = p
+	r.states[id] = stateUsername
+}
+
+// Remove oublie une session terminée
+func (r *registry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.programs, id)
+	delete(r.states, id)
+	delete(r.progress, id)
+}
+
+// Count retourne le nombre de sessions ouvertes
+func (r *registry) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.programs)
+}
+
+// SetState note l'écran courant d'une session ouverte
+func (r *registry) SetState(id string, state appState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.programs[id]; ok {
+		r.states[id] = state
+	}
+}
+
+// SetProgress note la partie en cours d'une session ouverte (0: aucune)
+func (r *registry) SetProgress(id string, progressID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.programs[id]; ok {
+		r.progress[id] = progressID
+	}
+}
+
+// Progress retourne la partie en cours d'une session ouverte (0: aucune)
+func (r *registry) Progress(id string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.progress[id]
+}
+
+// LiveProgress retourne les parties en cours des sessions ouvertes, que le
+// délai de reprise ne doit pas abandonner
+func (r *registry) LiveProgress() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make([]int, 0, len(r.progress))
+	for _, id := range r.progress {
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// CountByState retourne le nombre de sessions ouvertes sur chaque écran
+func (r *registry) CountByState() map[appState]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[appState]int, len(appStates))
+	for _, state := range r.states {
+		counts[state]++
+	}
+	return counts
+}
+
+// Broadcast envoie msg à toutes les sessions ouvertes
+func (r *registry) Broadcast(msg tea.Msg) {
+	r.mu.Lock()
+	programs := make([]*tea.Program, 0, len(r.programs))
+	for _, p := range r.programs {
+		programs = append(programs, p)
+	}
+	r.mu.Unlock()
+
+	// Send bloque tant que le programme n'a pas lu le message: hors du verrou
+	for _, p := range programs {
+		go p.Send(msg)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"log"
+	"math"
+	"quizz-ssh/models"
+	"quizz-ssh/ui"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Durées par défaut, modifiables par IDLE_TIMEOUT et MAX_SESSION_DURATION (0 désactive)
+const (
+	defaultIdleTimeout        = 15 * time.Minute
+	defaultMaxSessionDuration = 2 * time.Hour
+
+	// defaultShutdownGrace laisse aux joueurs le temps de voir l'annonce d'arrêt
+	// (SHUTDOWN_GRACE); shutdownMargin laisse ensuite aux sessions le temps de se fermer
+	defaultShutdownGrace = 30 * time.Second
+	shutdownMargin       = 5 * time.Second
+
+	// sessionCheckInterval espace les vérifications, sauf pendant le compte à
+	// rebours de l'avertissement, mis à jour chaque seconde
+	sessionCheckInterval = 5 * time.Second
+)
+
+var (
+	idleTimeout        = defaultIdleTimeout
+	maxSessionDuration = defaultMaxSessionDuration
+	shutdownGrace      = defaultShutdownGrace
+)
+
+// maintenanceMsg annonce l'arrêt du serveur à toutes les sessions
+type maintenanceMsg struct {
+	Deadline time.Time
+}
+
+// maintenanceTickMsg fait avancer le compte à rebours du bandeau de maintenance
+type maintenanceTickMsg struct{}
+
+func maintenanceTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return maintenanceTickMsg{}
+	})
+}
+
+// checkMaintenance ferme la session à l'échéance annoncée
+func (m *appModel) checkMaintenance() (tea.Model, tea.Cmd) {
+	if m.closing != "" {
+		return m, nil
+	}
+	if !time.Now().Before(m.maintenance) {
+		return m.closeForMaintenance()
+	}
+	return m, maintenanceTick()
+}
+
+// sessionTickMsg déclenche la vérification de l'inactivité et de la durée de session
+type sessionTickMsg struct{}
+
+func sessionTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return sessionTickMsg{}
+	})
+}
+
+// idleWarningDelay est la durée de l'avertissement avant la déconnexion
+func idleWarningDelay() time.Duration {
+	return min(time.Minute, idleTimeout/2)
+}
+
+// checkSession avertit le joueur inactif puis le déconnecte; la durée maximale
+// de session s'applique même à un joueur actif
+func (m *appModel) checkSession() (tea.Model, tea.Cmd) {
+	if m.closing != "" {
+		return m, nil
+	}
+
+	now := time.Now()
+	if maxSessionDuration > 0 && now.Sub(m.startedAt) >= maxSessionDuration {
+		return m.disconnect(m.lang.T("session.max_duration"), "durée maximale atteinte")
+	}
+	interval := sessionCheckInterval
+	if idleTimeout > 0 {
+		idle := now.Sub(m.lastInput)
+		if idle >= idleTimeout {
+			return m.disconnect(m.lang.T("session.idle_closed"), "inactivité")
+		}
+		warnAt := idleTimeout - idleWarningDelay()
+		m.idleWarning = idle >= warnAt
+		if m.idleWarning {
+			interval = time.Second
+		} else {
+			// Vérifier au plus tard au moment où l'avertissement doit apparaître
+			interval = max(time.Second, min(interval, warnAt-idle))
+		}
+	}
+	return m, sessionTick(interval)
+}
+
+// disconnect ferme la session en laissant le message à l'écran; une partie en
+// cours ne pourra pas être reprise et est enregistrée comme abandonnée
+func (m *appModel) disconnect(message, reason string) (tea.Model, tea.Cmd) {
+	who := m.username
+	if who == "" {
+		who = m.session.RemoteAddr().String()
+	}
+	log.Printf("⏱️  %s déconnecté (%s)", who, reason)
+
+	if m.progress.Status == models.AttemptInProgress {
+		m.abandonProgress()
+	}
+	return m.close(message)
+}
+
+// closeForMaintenance ferme la session à l'arrêt du serveur; contrairement à
+// une déconnexion pour inactivité, la partie en cours reste reprenable
+func (m *appModel) closeForMaintenance() (tea.Model, tea.Cmd) {
+	if quizModel, ok := m.subModel.(ui.QuizModel); ok && m.state == stateQuiz && m.progress.Status == models.AttemptInProgress {
+		m.saveProgress(quizModel)
+	}
+	return m.close(m.lang.T("session.maintenance_closed"))
+}
+
+// close quitte le programme en laissant message à l'écran
+func (m *appModel) close(message string) (tea.Model, tea.Cmd) {
+	m.idleWarning = false
+	m.closing = message
+	// Quitter l'écran alternatif d'abord, pour que le message reste affiché
+	return m, tea.Sequence(tea.ExitAltScreen, tea.Quit)
+}
+
+// sessionScreen affiche l'avertissement d'inactivité ou le message de déconnexion
+func (m *appModel) sessionScreen() string {
+	var b strings.Builder
+
+	if m.closing != "" {
+		b.WriteString(m.styles.Title.Render(m.closing) + "\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("session.reconnect")) + "\n")
+		return m.styles.Page.Render(b.String())
+	}
+
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	remaining := int(math.Ceil((idleTimeout - time.Since(m.lastInput)).Seconds()))
+	b.WriteString(m.styles.Title.Render(m.lang.T("session.idle_title")) + "\n\n")
+	b.WriteString(m.styles.Box.Render(m.styles.Question.Render(m.lang.T("session.idle_warning", max(0, remaining)))) + "\n\n")
+	b.WriteString(m.styles.Help.Render(m.lang.T("session.idle_help")) + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"quizz-ssh/models"
+	"strings"
+	"time"
+)
+
+// GetUserAchievements récupère les badges débloqués par un joueur
+func (d *Database) GetUserAchievements(username string) ([]models.UserAchievement, error) {
+	rows, err := d.query(
+		"SELECT username, code, unlocked_at FROM achievements WHERE username = ? ORDER BY unlocked_at",
+		username,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var unlocked []models.UserAchievement
+	for rows.Next() {
+		var a models.UserAchievement
+		if err := rows.Scan(&a.Username, &a.Code, &a.UnlockedAt); err != nil {
+			return nil, err
+		}
+		unlocked = append(unlocked, a)
+	}
+	return unlocked, rows.Err()
+}
+
+// GetAchievementCodes récupère les codes des badges de plusieurs joueurs (pour les classements)
+func (d *Database) GetAchievementCodes(usernames []string) (map[string][]string, error) {
+	codes := make(map[string][]string)
+	if len(usernames) == 0 {
+		return codes, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(usernames)), ",")
+	args := make([]any, len(usernames))
+	for i, u := range usernames {
+		args[i] = u
+	}
+
+	rows, err := d.query(
+		"SELECT username, code FROM achievements WHERE username IN ("+placeholders+")",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var username, code string
+		if err := rows.Scan(&username, &code); err != nil {
+			return nil, err
+		}
+		codes[username] = append(codes[username], code)
+	}
+	return codes, rows.Err()
+}
+
+// UnlockAchievement enregistre un badge; ne fait rien s'il était déjà débloqué
+func (d *Database) UnlockAchievement(username, code string) error {
+	_, err := d.exec(
+		"INSERT INTO achievements (username, code, unlocked_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+		username, code, time.Now(),
+	)
+	return err
+}
+
+// GetPlayStreak compte les jours consécutifs (dans le fuseau local) où le joueur a joué,
+// en partant d'aujourd'hui ou d'hier
+func (d *Database) GetPlayStreak(username string) (int, error) {
+	since := time.Now().AddDate(-1, 0, 0)
+	rows, err := d.query(
+		"SELECT created_at FROM scores WHERE username = ? AND "+d.dialect.since("created_at"),
+		username, since,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			return 0, err
+		}
+		dates = append(dates, createdAt)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return playStreak(dates, time.Now()), nil
+}
+
+// playStreak compte les jours consécutifs joués jusqu'à now (ou la veille si
+// le joueur n'a pas encore joué aujourd'hui)
+func playStreak(dates []time.Time, now time.Time) int {
+	days := make(map[string]bool, len(dates))
+	for _, t := range dates {
+		days[t.Local().Format(time.DateOnly)] = true
+	}
+
+	day := now
+	if !days[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for days[day.Format(time.DateOnly)] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
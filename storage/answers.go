@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"quizz-ssh/models"
+	"time"
+)
+
+// GetRecordedAnswers récupère toutes les réponses enregistrées, dans l'ordre où elles ont été données
+func (d *Database) GetRecordedAnswers() ([]models.RecordedAnswer, error) {
+	rows, err := d.query(`
+		SELECT a.question_id, a.revision, a.chosen, a.correct, a.duration_ms, s.username
+		FROM attempt_answers a
+		JOIN scores s ON s.id = a.score_id
+		ORDER BY a.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []models.RecordedAnswer
+	for rows.Next() {
+		var a models.RecordedAnswer
+		var durationMs int64
+		if err := rows.Scan(&a.QuestionID, &a.Revision, &a.Chosen, &a.Correct, &durationMs, &a.Username); err != nil {
+			return nil, err
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		answers = append(answers, a)
+	}
+	return answers, rows.Err()
+}
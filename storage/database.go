@@ -2,105 +2,293 @@ package storage
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"quizz-ssh/models"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
 )
 
+// Database est l'implémentation SQL de Store, sur SQLite ou PostgreSQL
 type Database struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect dialect
 }
 
-// NewDatabase crée une nouvelle connexion à la base de données
+// NewDatabase ouvre la base SQLite et applique les migrations en attente
 func NewDatabase(dbPath string) (*Database, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	d, err := OpenDatabase(dbPath)
 	if err != nil {
 		return nil, err
 	}
+	if _, _, err := d.Migrate(nil); err != nil {
+		d.Close()
+		return nil, fmt.Errorf("migrations: %w", err)
+	}
+	return d, nil
+}
 
-	// Créer les tables si elles n'existent pas
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS scores (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			username TEXT NOT NULL,
-			category TEXT NOT NULL,
-			score INTEGER NOT NULL,
-			total INTEGER NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);
-		CREATE INDEX IF NOT EXISTS idx_category ON scores(category);
-		CREATE INDEX IF NOT EXISTS idx_username ON scores(username);
-	`)
+// OpenDatabase ouvre la base SQLite sans appliquer de migration
+func OpenDatabase(dbPath string) (*Database, error) {
+	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, err
 	}
+	return &Database{db: db, dialect: dialectSQLite}, nil
+}
 
-	return &Database{db: db}, nil
+// OpenPostgres ouvre une base PostgreSQL sans appliquer de migration
+func OpenPostgres(dsn string) (*Database, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Database{db: db, dialect: dialectPostgres}, nil
 }
 
-// SaveScore enregistre un score
-func (d *Database) SaveScore(score models.Score) error {
-	_, err := d.db.Exec(
-		"INSERT INTO scores (username, category, score, total, created_at) VALUES (?, ?, ?, ?, ?)",
-		score.Username, score.Category, score.Score, score.Total, time.Now(),
+// SaveAttempt enregistre une partie et la réponse donnée à chaque question
+// (avec la version de la question posée), et retourne l'identifiant de la partie
+func (d *Database) SaveAttempt(score models.Score, answers []models.Answer) (int, error) {
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer rollback()
+
+	id, err := d.dialect.insert(tx,
+		"INSERT INTO scores (username, category, score, total, duration_ms, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		score.Username, score.Category, score.Score, score.Total, score.Duration.Milliseconds(), time.Now(),
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+
+	for _, a := range answers {
+		_, err := tx.Exec(d.dialect.rebind(
+			"INSERT INTO attempt_answers (score_id, question_id, revision, chosen, correct, duration_ms) VALUES (?, ?, ?, ?, ?, ?)"),
+			id, a.QuestionID, a.Revision, a.Chosen, a.Correct, a.Duration.Milliseconds(),
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
 }
 
-// GetLeaderboard récupère le top 10 pour une catégorie (ou global si category == "")
-func (d *Database) GetLeaderboard(category string, limit int) ([]models.Score, error) {
-	var rows *sql.Rows
-	var err error
+// ErrScoreNotFound est retournée pour une partie inexistante
+var ErrScoreNotFound = errors.New("partie introuvable")
 
-	if category == "" || category == "global" {
-		// Leaderboard global: meilleur score par utilisateur toutes catégories confondues
-		rows, err = d.db.Query(`
-			SELECT username, 'global' as category, SUM(score) as total_score, SUM(total) as total_questions, MAX(created_at) as created_at
-			FROM scores
-			GROUP BY username
-			ORDER BY total_score DESC
-			LIMIT ?
-		`, limit)
-	} else {
-		// Leaderboard par catégorie: meilleur score par utilisateur pour cette catégorie
-		rows, err = d.db.Query(`
-			SELECT username, category, MAX(score) as best_score, total, created_at
-			FROM scores
-			WHERE category = ?
-			GROUP BY username
-			ORDER BY best_score DESC
-			LIMIT ?
-		`, category, limit)
+// DeleteScore supprime une partie et ses réponses (modération d'un score frauduleux)
+func (d *Database) DeleteScore(id int) error {
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return err
 	}
+	defer rollback()
 
+	if _, err := tx.Exec(d.dialect.rebind("DELETE FROM attempt_answers WHERE score_id = ?"), id); err != nil {
+		return err
+	}
+	res, err := tx.Exec(d.dialect.rebind("DELETE FROM scores WHERE id = ?"), id)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrScoreNotFound
+	}
+	return tx.Commit()
+}
+
+// GetLeaderboard récupère le top pour une catégorie (ou global si category == "")
+// en ne comptant que les parties jouées pendant la période
+func (d *Database) GetLeaderboard(category string, period models.Period, limit int) ([]models.Score, error) {
+	scores, _, err := d.GetLeaderboardPage(category, period, "", 0, limit)
+	return scores, err
+}
+
+// GetLeaderboardPage récupère une page du classement, éventuellement filtrée sur
+// une partie du pseudo (les rangs restent ceux du classement complet), ainsi que
+// le nombre total de lignes correspondant au filtre
+func (d *Database) GetLeaderboardPage(category string, period models.Period, search string, offset, limit int) ([]models.Score, int, error) {
+	board, args := d.leaderboardQuery(category, period)
+	pattern := "%" + escapeLike(search) + "%"
+	like := d.dialect.like()
+
+	var total int
+	err := d.queryRow(
+		board+`SELECT COUNT(*) FROM board WHERE username `+like+` ? ESCAPE '\'`,
+		append(args, pattern)...,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := d.query(
+		board+`SELECT username, category, score, total, duration_ms, created_at, rank
+			FROM board
+			WHERE username `+like+` ? ESCAPE '\'
+			ORDER BY rank, created_at ASC, username
+			LIMIT ? OFFSET ?`,
+		append(args, pattern, limit, offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	var scores []models.Score
 	for rows.Next() {
-		var score models.Score
-		var createdAtStr string
-		err := rows.Scan(&score.Username, &score.Category, &score.Score, &score.Total, &createdAtStr)
+		score, err := scanRankedScore(rows)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		// Parse la date SQLite
-		score.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAtStr)
 		scores = append(scores, score)
 	}
 
-	return scores, nil
+	return scores, total, rows.Err()
+}
+
+// GetUserRank récupère la ligne de classement d'un joueur; ok vaut false s'il n'est pas classé
+func (d *Database) GetUserRank(category string, period models.Period, username string) (score models.Score, ok bool, err error) {
+	board, args := d.leaderboardQuery(category, period)
+	rows, err := d.query(
+		board+`SELECT username, category, score, total, duration_ms, created_at, rank
+			FROM board
+			WHERE username = ?`,
+		append(args, username)...,
+	)
+	if err != nil {
+		return score, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return score, false, rows.Err()
+	}
+	score, err = scanRankedScore(rows)
+	return score, err == nil, err
+}
+
+// leaderboardQuery construit la CTE "board" du classement: une ligne par joueur
+// avec son rang (partagé en cas d'égalité)
+func (d *Database) leaderboardQuery(category string, period models.Period) (string, []any) {
+	since, args := d.dialect.periodFilter(period)
+
+	if category == "" || category == "global" {
+		// Leaderboard global: cumul des scores par utilisateur toutes catégories confondues,
+		// départagé par le taux de réussite puis le temps total
+		return `
+			WITH totals AS (
+				SELECT username,
+					SUM(score) AS total_score,
+					SUM(total) AS total_questions,
+					SUM(duration_ms) AS total_ms,
+					MAX(created_at) AS last_played
+				FROM scores
+				WHERE 1 = 1 ` + since + `
+				GROUP BY username
+			),
+			board AS (
+				SELECT username, 'global' AS category, total_score AS score, total_questions AS total,
+					total_ms AS duration_ms, last_played AS created_at,
+					RANK() OVER (
+						ORDER BY total_score DESC,
+							CAST(total_score AS REAL) / NULLIF(total_questions, 0) DESC,
+							total_ms ASC
+					) AS rank
+				FROM totals
+			)
+		`, args
+	}
+
+	// Leaderboard par catégorie: meilleure partie de chaque utilisateur
+	// (taux de réussite, puis temps, puis date), classée avec rangs partagés
+	return `
+		WITH ranked AS (
+			SELECT username, category, score, total, duration_ms, created_at,
+				CAST(score AS REAL) / NULLIF(total, 0) AS pct,
+				ROW_NUMBER() OVER (
+					PARTITION BY username
+					ORDER BY CAST(score AS REAL) / NULLIF(total, 0) DESC, duration_ms ASC, created_at ASC, id ASC
+				) AS attempt_rank
+			FROM scores
+			WHERE category = ? ` + since + `
+		),
+		board AS (
+			SELECT username, category, score, total, duration_ms, created_at,
+				RANK() OVER (ORDER BY pct DESC, duration_ms ASC) AS rank
+			FROM ranked
+			WHERE attempt_rank = 1
+		)
+	`, append([]any{category}, args...)
+}
+
+func scanRankedScore(rows *timedRows) (models.Score, error) {
+	var score models.Score
+	var createdAtStr string
+	var durationMs int64
+	err := rows.Scan(&score.Username, &score.Category, &score.Score, &score.Total, &durationMs, &createdAtStr, &score.Rank)
+	if err != nil {
+		return score, err
+	}
+	score.Duration = time.Duration(durationMs) * time.Millisecond
+	score.CreatedAt = parseTime(createdAtStr)
+	return score, nil
+}
+
+// escapeLike protège les caractères spéciaux de LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// parseTime parse une date SQLite dans l'un des formats écrits par le driver
+// (RFC 3339 quand le driver a lui-même converti la date, cas de PostgreSQL)
+func parseTime(value string) time.Time {
+	layouts := append([]string{time.RFC3339Nano}, sqlite3.SQLiteTimestampFormats...)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// SaveDuel enregistre le résultat d'un duel
+func (d *Database) SaveDuel(duel models.Duel) error {
+	_, err := d.exec(
+		"INSERT INTO duels (player1, player2, score1, score2, total, winner, forfeit, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		duel.Player1, duel.Player2, duel.Score1, duel.Score2, duel.Total, duel.Winner, duel.Forfeit, time.Now(),
+	)
+	return err
+}
+
+// GetDuelRecord récupère le bilan victoires/défaites d'un joueur en duel
+func (d *Database) GetDuelRecord(username string) (models.DuelRecord, error) {
+	var record models.DuelRecord
+	err := d.queryRow(`
+		SELECT
+			COALESCE(SUM(CASE WHEN winner = ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN winner != '' AND winner != ? THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN winner = '' THEN 1 ELSE 0 END), 0)
+		FROM duels
+		WHERE player1 = ? OR player2 = ?
+	`, username, username, username, username).Scan(&record.Wins, &record.Losses, &record.Draws)
+	return record, err
 }
 
 // GetUserBestScore récupère le meilleur score d'un utilisateur pour une catégorie
 func (d *Database) GetUserBestScore(username, category string) (int, error) {
 	var bestScore int
-	err := d.db.QueryRow(
-		"SELECT IFNULL(MAX(score), 0) FROM scores WHERE username = ? AND category = ?",
+	err := d.queryRow(
+		"SELECT COALESCE(MAX(score), 0) FROM scores WHERE username = ? AND category = ?",
 		username, category,
 	).Scan(&bestScore)
 	return bestScore, err
@@ -113,7 +301,7 @@ func (d *Database) Close() error {
 
 // GetCategories récupère toutes les catégories distinctes
 func (d *Database) GetCategories() ([]string, error) {
-	rows, err := d.db.Query("SELECT DISTINCT category FROM scores ORDER BY category")
+	rows, err := d.query("SELECT DISTINCT category FROM scores ORDER BY category")
 	if err != nil {
 		return nil, err
 	}
@@ -130,11 +318,8 @@ func (d *Database) GetCategories() ([]string, error) {
 	return categories, nil
 }
 
-func (d *Database) GetStats() (string, error) {
-	var totalScores, uniqueUsers int
-	err := d.db.QueryRow("SELECT COUNT(*), COUNT(DISTINCT username) FROM scores").Scan(&totalScores, &uniqueUsers)
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("Total parties: %d | Joueurs uniques: %d", totalScores, uniqueUsers), nil
+// GetStats compte les parties jouées et les joueurs distincts
+func (d *Database) GetStats() (games, players int, err error) {
+	err = d.queryRow("SELECT COUNT(*), COUNT(DISTINCT username) FROM scores").Scan(&games, &players)
+	return games, players, err
 }
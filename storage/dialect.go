@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"database/sql"
+	"quizz-ssh/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// dialect distingue les moteurs SQL supportés par Database
+type dialect int
+
+const (
+	dialectSQLite dialect = iota
+	dialectPostgres
+)
+
+// rebind convertit les paramètres "?" en "$1, $2..." pour PostgreSQL,
+// en ignorant ceux qui apparaissent dans une chaîne littérale
+func (dl dialect) rebind(query string) string {
+	if dl != dialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	n := 0
+	inString := false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inString = !inString
+			b.WriteRune(r)
+		case r == '?' && !inString:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// since retourne la condition SQL "la colonne est postérieure au paramètre".
+// SQLite stocke les dates en texte avec leur fuseau: la comparaison passe par julianday.
+func (dl dialect) since(column string) string {
+	if dl == dialectPostgres {
+		return column + " >= ?"
+	}
+	return "julianday(" + column + ") >= julianday(?)"
+}
+
+// like retourne l'opérateur de recherche insensible à la casse
+func (dl dialect) like() string {
+	if dl == dialectPostgres {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// forUpdate verrouille les lignes lues jusqu'à la fin de la transaction.
+// SQLite n'a qu'un verrou d'écriture global, pris par la première écriture.
+func (dl dialect) forUpdate() string {
+	if dl == dialectPostgres {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
+// periodFilter retourne la condition SQL limitant les parties à la période
+func (dl dialect) periodFilter(period models.Period) (string, []any) {
+	start := period.Start(time.Now())
+	if start.IsZero() {
+		return "", nil
+	}
+	return "AND " + dl.since("created_at"), []any{start}
+}
+
+// queryDuration mesure les requêtes passées par query, queryRow et exec, et
+// les transactions ouvertes par begin, de leur début à leur fin (lecture des
+// lignes comprise)
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "quiz_ssh_db_query_duration_seconds",
+	Help:    "Durée des requêtes SQL, par type (query, exec, tx).",
+	Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+}, []string{"kind"})
+
+// query exécute une requête; sa durée, lecture des lignes comprise, est
+// mesurée à la fermeture des lignes
+func (d *Database) query(query string, args ...any) (*timedRows, error) {
+	start := time.Now()
+	rows, err := d.db.Query(d.dialect.rebind(query), args...)
+	if err != nil {
+		observe("query", start)
+		return nil, err
+	}
+	return &timedRows{Rows: rows, start: start}, nil
+}
+
+// queryRow exécute une requête d'une ligne; sa durée est mesurée au Scan
+func (d *Database) queryRow(query string, args ...any) timedRow {
+	return timedRow{Row: d.db.QueryRow(d.dialect.rebind(query), args...), start: time.Now()}
+}
+
+func (d *Database) exec(query string, args ...any) (sql.Result, error) {
+	defer observe("exec", time.Now())
+	return d.db.Exec(d.dialect.rebind(query), args...)
+}
+
+// begin ouvre une transaction; rollback l'annule si elle n'a pas été validée
+// et mesure sa durée: l'appeler en defer
+func (d *Database) begin() (tx *sql.Tx, rollback func(), err error) {
+	start := time.Now()
+	if tx, err = d.db.Begin(); err != nil {
+		return nil, nil, err
+	}
+	return tx, func() {
+		tx.Rollback()
+		observe("tx", start)
+	}, nil
+}
+
+// timedRows mesure une requête jusqu'à la fermeture de ses lignes: les
+// moteurs ne lisent souvent les résultats qu'à l'appel de Next
+type timedRows struct {
+	*sql.Rows
+	start  time.Time
+	closed bool
+}
+
+// Close ferme les lignes; seul le premier appel est mesuré
+func (r *timedRows) Close() error {
+	if !r.closed {
+		r.closed = true
+		defer observe("query", r.start)
+	}
+	return r.Rows.Close()
+}
+
+// timedRow mesure une requête d'une ligne jusqu'à la lecture de celle-ci
+type timedRow struct {
+	*sql.Row
+	start time.Time
+}
+
+func (r timedRow) Scan(dest ...any) error {
+	defer observe("query", r.start)
+	return r.Row.Scan(dest...)
+}
+
+func observe(kind string, start time.Time) {
+	queryDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
+
+// insert exécute un INSERT dans la transaction et retourne l'identifiant créé
+// (PostgreSQL ne supporte pas LastInsertId: on passe par RETURNING id)
+func (dl dialect) insert(tx *sql.Tx, query string, args ...any) (int, error) {
+	if dl == dialectPostgres {
+		var id int
+		err := tx.QueryRow(dl.rebind(query+" RETURNING id"), args...).Scan(&id)
+		return id, err
+	}
+
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	return int(id), err
+}
@@ -0,0 +1,905 @@
+package storage
+
+import (
+	"quizz-ssh/models"
+	"quizz-ssh/rating"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore implémente Store en mémoire: rien n'est conservé à l'arrêt du serveur.
+// Il reproduit les règles de classement de Database et sert aux tests et démonstrations.
+type MemoryStore struct {
+	mu            sync.Mutex
+	scores        []models.Score
+	duels         []models.Duel
+	ratings       map[ratingKey]models.Rating
+	ratingHistory []models.RatingChange
+	achievements  map[string][]models.UserAchievement
+	answers       map[int][]models.Answer   // réponses par partie
+	revisions     map[int][]models.Question // versions successives de chaque question
+	deleted       map[int]bool
+	disabled      map[int]bool
+	reports       []models.Report
+	preferences   map[string]models.Preferences
+	progress      map[int]models.Progress // parties en cours ou interrompues
+	nextID        int
+}
+
+type ratingKey struct {
+	username string
+	category string
+}
+
+// NewMemoryStore crée un stockage en mémoire vide
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		ratings:      make(map[ratingKey]models.Rating),
+		achievements: make(map[string][]models.UserAchievement),
+		answers:      make(map[int][]models.Answer),
+		revisions:    make(map[int][]models.Question),
+		deleted:      make(map[int]bool),
+		disabled:     make(map[int]bool),
+		preferences:  make(map[string]models.Preferences),
+		progress:     make(map[int]models.Progress),
+	}
+}
+
+// SaveAttempt enregistre une partie et la réponse donnée à chaque question
+func (m *MemoryStore) SaveAttempt(score models.Score, answers []models.Answer) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	score.ID = m.nextID
+	score.Rank = 0
+	score.CreatedAt = time.Now()
+	m.scores = append(m.scores, score)
+	m.answers[score.ID] = append([]models.Answer(nil), answers...)
+	return score.ID, nil
+}
+
+// DeleteScore supprime une partie et ses réponses
+func (m *MemoryStore) DeleteScore(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, s := range m.scores {
+		if s.ID == id {
+			m.scores = append(m.scores[:i], m.scores[i+1:]...)
+			delete(m.answers, id)
+			return nil
+		}
+	}
+	return ErrScoreNotFound
+}
+
+// GetRecordedAnswers récupère toutes les réponses enregistrées, dans l'ordre où elles ont été données
+func (m *MemoryStore) GetRecordedAnswers() ([]models.RecordedAnswer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var recorded []models.RecordedAnswer
+	for _, s := range m.scores {
+		for _, a := range m.answers[s.ID] {
+			recorded = append(recorded, models.RecordedAnswer{Answer: a, Username: s.Username})
+		}
+	}
+	return recorded, nil
+}
+
+// GetLeaderboard récupère le top pour une catégorie (ou global si category == "")
+func (m *MemoryStore) GetLeaderboard(category string, period models.Period, limit int) ([]models.Score, error) {
+	scores, _, err := m.GetLeaderboardPage(category, period, "", 0, limit)
+	return scores, err
+}
+
+// GetLeaderboardPage récupère une page du classement filtrée sur une partie du pseudo
+func (m *MemoryStore) GetLeaderboardPage(category string, period models.Period, search string, offset, limit int) ([]models.Score, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	search = strings.ToLower(search)
+	var matching []models.Score
+	for _, s := range m.board(category, period) {
+		if strings.Contains(strings.ToLower(s.Username), search) {
+			matching = append(matching, s)
+		}
+	}
+
+	total := len(matching)
+	if offset >= total {
+		return nil, total, nil
+	}
+	end := min(offset+limit, total)
+	return matching[offset:end], total, nil
+}
+
+// GetUserRank récupère la ligne de classement d'un joueur; ok vaut false s'il n'est pas classé
+func (m *MemoryStore) GetUserRank(category string, period models.Period, username string) (models.Score, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.board(category, period) {
+		if s.Username == username {
+			return s, true, nil
+		}
+	}
+	return models.Score{}, false, nil
+}
+
+// board calcule le classement complet avec les mêmes règles que leaderboardQuery
+func (m *MemoryStore) board(category string, period models.Period) []models.Score {
+	start := period.Start(time.Now())
+	var board []models.Score
+
+	if category == "" || category == "global" {
+		// Cumul par joueur toutes catégories confondues
+		totals := make(map[string]*models.Score)
+		for _, s := range m.scores {
+			if s.CreatedAt.Before(start) {
+				continue
+			}
+			t, ok := totals[s.Username]
+			if !ok {
+				t = &models.Score{Username: s.Username, Category: "global"}
+				totals[s.Username] = t
+			}
+			t.Score += s.Score
+			t.Total += s.Total
+			t.Duration += s.Duration
+			if s.CreatedAt.After(t.CreatedAt) {
+				t.CreatedAt = s.CreatedAt
+			}
+		}
+		for _, t := range totals {
+			board = append(board, *t)
+		}
+		rankScores(board, func(a, b models.Score) int {
+			if a.Score != b.Score {
+				return b.Score - a.Score
+			}
+			if c := compareRate(b, a); c != 0 {
+				return c
+			}
+			return compareDuration(a, b)
+		})
+		return board
+	}
+
+	// Meilleure partie de chaque joueur dans la catégorie
+	best := make(map[string]models.Score)
+	for _, s := range m.scores {
+		if s.Category != category || s.CreatedAt.Before(start) {
+			continue
+		}
+		if current, ok := best[s.Username]; !ok || betterAttempt(s, current) {
+			best[s.Username] = s
+		}
+	}
+	for _, s := range best {
+		board = append(board, s)
+	}
+	rankScores(board, func(a, b models.Score) int {
+		if c := compareRate(b, a); c != 0 {
+			return c
+		}
+		return compareDuration(a, b)
+	})
+	return board
+}
+
+// rankScores trie le classement et attribue les rangs (partagés quand cmp vaut 0),
+// les ex-aequo étant affichés par date puis par pseudo
+func rankScores(board []models.Score, cmp func(a, b models.Score) int) {
+	sort.Slice(board, func(i, j int) bool {
+		if c := cmp(board[i], board[j]); c != 0 {
+			return c < 0
+		}
+		if !board[i].CreatedAt.Equal(board[j].CreatedAt) {
+			return board[i].CreatedAt.Before(board[j].CreatedAt)
+		}
+		return board[i].Username < board[j].Username
+	})
+	for i := range board {
+		if i > 0 && cmp(board[i-1], board[i]) == 0 {
+			board[i].Rank = board[i-1].Rank
+		} else {
+			board[i].Rank = i + 1
+		}
+	}
+}
+
+// betterAttempt indique si a est une meilleure partie que b
+// (taux de réussite, puis temps, puis date, puis ordre d'enregistrement)
+func betterAttempt(a, b models.Score) bool {
+	if c := compareRate(b, a); c != 0 {
+		return c < 0
+	}
+	if c := compareDuration(a, b); c != 0 {
+		return c < 0
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+func compareRate(a, b models.Score) int {
+	ra, rb := scoreRate(a), scoreRate(b)
+	switch {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareDuration(a, b models.Score) int {
+	switch {
+	case a.Duration < b.Duration:
+		return -1
+	case a.Duration > b.Duration:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func scoreRate(s models.Score) float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Score) / float64(s.Total)
+}
+
+// GetCategories récupère toutes les catégories distinctes
+func (m *MemoryStore) GetCategories() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var categories []string
+	for _, s := range m.scores {
+		if !seen[s.Category] {
+			seen[s.Category] = true
+			categories = append(categories, s.Category)
+		}
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
+
+// GetStats compte les parties jouées et les joueurs distincts
+func (m *MemoryStore) GetStats() (games, players int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make(map[string]bool)
+	for _, s := range m.scores {
+		users[s.Username] = true
+	}
+	return len(m.scores), len(users), nil
+}
+
+// GetUserBestScore récupère le meilleur score d'un utilisateur pour une catégorie
+func (m *MemoryStore) GetUserBestScore(username, category string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	best := 0
+	for _, s := range m.scores {
+		if s.Username == username && s.Category == category && s.Score > best {
+			best = s.Score
+		}
+	}
+	return best, nil
+}
+
+// GetUserStats récupère les statistiques cumulées d'un joueur
+func (m *MemoryStore) GetUserStats(username string) (models.UserStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stats models.UserStats
+	for _, s := range m.scores {
+		if s.Username != username {
+			continue
+		}
+		stats.Attempts++
+		stats.Correct += s.Score
+		stats.Questions += s.Total
+		stats.TotalTime += s.Duration
+		if stats.FirstPlayed.IsZero() || s.CreatedAt.Before(stats.FirstPlayed) {
+			stats.FirstPlayed = s.CreatedAt
+		}
+		if s.CreatedAt.After(stats.LastPlayed) {
+			stats.LastPlayed = s.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+// GetUserHistory récupère les dernières parties d'un joueur, de la plus récente à la plus ancienne
+func (m *MemoryStore) GetUserHistory(username string, limit int) ([]models.Score, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var history []models.Score
+	for i := len(m.scores) - 1; i >= 0 && len(history) < limit; i-- {
+		if m.scores[i].Username == username {
+			history = append(history, m.scores[i])
+		}
+	}
+	return history, nil
+}
+
+// GetUserBestScores récupère la meilleure partie d'un joueur dans chaque catégorie
+func (m *MemoryStore) GetUserBestScores(username string) ([]models.Score, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	best := make(map[string]models.Score)
+	for _, s := range m.scores {
+		if s.Username != username {
+			continue
+		}
+		if current, ok := best[s.Category]; !ok || betterAttempt(s, current) {
+			best[s.Category] = s
+		}
+	}
+
+	scores := make([]models.Score, 0, len(best))
+	for _, s := range best {
+		scores = append(scores, s)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Category < scores[j].Category })
+	return scores, nil
+}
+
+// GetUserCategoryRates calcule, pour chaque catégorie de question, le meilleur
+// taux de bonnes réponses d'un joueur sur une partie
+func (m *MemoryStore) GetUserCategoryRates(username string) (map[string]float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rates := make(map[string]float64)
+	for _, s := range m.scores {
+		if s.Username != username {
+			continue
+		}
+		correct := make(map[string]int)
+		total := make(map[string]int)
+		for _, a := range m.answers[s.ID] {
+			revisions := m.revisions[a.QuestionID]
+			if a.Revision < 1 || a.Revision > len(revisions) {
+				continue
+			}
+			category := revisions[a.Revision-1].Category
+			total[category]++
+			if a.Correct {
+				correct[category]++
+			}
+		}
+		for category, n := range total {
+			rates[category] = max(rates[category], float64(correct[category])/float64(n))
+		}
+	}
+	return rates, nil
+}
+
+// GetPlayStreak compte les jours consécutifs où le joueur a joué, en partant d'aujourd'hui ou d'hier
+func (m *MemoryStore) GetPlayStreak(username string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var dates []time.Time
+	for _, s := range m.scores {
+		if s.Username == username {
+			dates = append(dates, s.CreatedAt)
+		}
+	}
+	return playStreak(dates, time.Now()), nil
+}
+
+// SaveDuel enregistre le résultat d'un duel
+func (m *MemoryStore) SaveDuel(duel models.Duel) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	duel.ID = m.nextID
+	duel.CreatedAt = time.Now()
+	m.duels = append(m.duels, duel)
+	return nil
+}
+
+// GetDuelRecord récupère le bilan victoires/défaites d'un joueur en duel
+func (m *MemoryStore) GetDuelRecord(username string) (models.DuelRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var record models.DuelRecord
+	for _, d := range m.duels {
+		if d.Player1 != username && d.Player2 != username {
+			continue
+		}
+		switch d.Winner {
+		case username:
+			record.Wins++
+		case "":
+			record.Draws++
+		default:
+			record.Losses++
+		}
+	}
+	return record, nil
+}
+
+// GetRating récupère le niveau d'un joueur (niveau initial s'il n'a jamais joué)
+func (m *MemoryStore) GetRating(username, category string) (models.Rating, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if r, ok := m.ratings[ratingKey{username, category}]; ok {
+		return r, nil
+	}
+	return models.Rating{Username: username, Category: category, Rating: rating.Initial}, nil
+}
+
+// UpdateRatings met à jour les niveaux de joueurs dans une catégorie: update
+// reçoit leurs niveaux actuels (dans l'ordre de usernames) et retourne les nouveaux
+func (m *MemoryStore) UpdateRatings(category, source string, usernames []string, update func(current []models.Rating) []float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := make([]models.Rating, len(usernames))
+	for i, username := range usernames {
+		r, ok := m.ratings[ratingKey{username, category}]
+		if !ok {
+			r = models.Rating{Username: username, Category: category, Rating: rating.Initial}
+		}
+		current[i] = r
+	}
+
+	now := time.Now()
+	for i, newRating := range update(current) {
+		r := current[i]
+		r.Rating = newRating
+		r.Games++
+		r.UpdatedAt = now
+		m.ratings[ratingKey{r.Username, category}] = r
+
+		m.ratingHistory = append(m.ratingHistory, models.RatingChange{
+			Username:  r.Username,
+			Category:  category,
+			Rating:    newRating,
+			Delta:     newRating - current[i].Rating,
+			Source:    source,
+			CreatedAt: now,
+		})
+	}
+	return nil
+}
+
+// GetRatingLeaderboard récupère les meilleurs niveaux pour une catégorie ("global" pour le niveau général)
+func (m *MemoryStore) GetRatingLeaderboard(category string, limit int) ([]models.Rating, error) {
+	if category == "" {
+		category = "global"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ratings []models.Rating
+	for _, r := range m.ratings {
+		if r.Category == category {
+			ratings = append(ratings, r)
+		}
+	}
+	sort.Slice(ratings, func(i, j int) bool {
+		a, b := ratings[i], ratings[j]
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		if a.Games != b.Games {
+			return a.Games > b.Games
+		}
+		return a.Username < b.Username
+	})
+	if len(ratings) > limit {
+		ratings = ratings[:limit]
+	}
+	return ratings, nil
+}
+
+// GetRatingHistory récupère les dernières évolutions de niveau d'un joueur, de la plus récente à la plus ancienne
+func (m *MemoryStore) GetRatingHistory(username, category string, limit int) ([]models.RatingChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var history []models.RatingChange
+	for i := len(m.ratingHistory) - 1; i >= 0 && len(history) < limit; i-- {
+		c := m.ratingHistory[i]
+		if c.Username == username && c.Category == category {
+			history = append(history, c)
+		}
+	}
+	return history, nil
+}
+
+// GetUserAchievements récupère les badges débloqués par un joueur
+func (m *MemoryStore) GetUserAchievements(username string) ([]models.UserAchievement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]models.UserAchievement(nil), m.achievements[username]...), nil
+}
+
+// GetAchievementCodes récupère les codes des badges de plusieurs joueurs (pour les classements)
+func (m *MemoryStore) GetAchievementCodes(usernames []string) (map[string][]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	codes := make(map[string][]string)
+	for _, username := range usernames {
+		if _, done := codes[username]; done {
+			continue
+		}
+		for _, a := range m.achievements[username] {
+			codes[username] = append(codes[username], a.Code)
+		}
+	}
+	return codes, nil
+}
+
+// UnlockAchievement enregistre un badge; ne fait rien s'il était déjà débloqué
+func (m *MemoryStore) UnlockAchievement(username, code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, a := range m.achievements[username] {
+		if a.Code == code {
+			return nil
+		}
+	}
+	m.achievements[username] = append(m.achievements[username], models.UserAchievement{
+		Username:   username,
+		Code:       code,
+		UnlockedAt: time.Now(),
+	})
+	return nil
+}
+
+// CountQuestions compte les questions de la banque
+func (m *MemoryStore) CountQuestions() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.revisions) - len(m.deleted), nil
+}
+
+// GetQuestions récupère la version courante de toutes les questions de la banque
+func (m *MemoryStore) GetQuestions() ([]models.Question, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var questions []models.Question
+	for id, revisions := range m.revisions {
+		if !m.deleted[id] {
+			q := revisions[len(revisions)-1]
+			q.Disabled = m.disabled[id]
+			questions = append(questions, q)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+	return questions, nil
+}
+
+// GetQuestion récupère la version courante d'une question
+func (m *MemoryStore) GetQuestion(id int) (models.Question, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions, ok := m.revisions[id]
+	if !ok || m.deleted[id] {
+		return models.Question{}, ErrQuestionNotFound
+	}
+	q := revisions[len(revisions)-1]
+	q.Disabled = m.disabled[id]
+	return q, nil
+}
+
+// GetQuestionRevision récupère une version donnée d'une question
+func (m *MemoryStore) GetQuestionRevision(id, revision int) (models.Question, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions, ok := m.revisions[id]
+	if !ok || m.deleted[id] || revision < 1 || revision > len(revisions) {
+		return models.Question{}, ErrQuestionNotFound
+	}
+	q := revisions[revision-1]
+	q.Disabled = m.disabled[id]
+	return q, nil
+}
+
+// ImportQuestions ajoute des questions à la banque; les identifiants du fichier sont ignorés
+func (m *MemoryStore) ImportQuestions(questions []models.Question) (int, error) {
+	if err := validateImport(questions); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, q := range questions {
+		m.createQuestion(q)
+	}
+	return len(questions), nil
+}
+
+// SaveQuestion crée la question (ID nul) ou en enregistre une nouvelle version
+func (m *MemoryStore) SaveQuestion(q models.Question) (models.Question, error) {
+	if err := q.Validate(); err != nil {
+		return q, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if q.ID == 0 {
+		return m.createQuestion(q), nil
+	}
+	revisions, ok := m.revisions[q.ID]
+	if !ok || m.deleted[q.ID] {
+		return q, ErrQuestionNotFound
+	}
+	q.Revision = len(revisions) + 1
+	q.Options = append([]string(nil), q.Options...)
+	q.Translations = copyTranslations(q.Translations)
+	q.Disabled = m.disabled[q.ID]
+	m.revisions[q.ID] = append(revisions, q)
+	return q, nil
+}
+
+// DeleteQuestion retire une question de la banque (ses versions restent pour l'historique)
+func (m *MemoryStore) DeleteQuestion(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.revisions[id]; !ok || m.deleted[id] {
+		return ErrQuestionNotFound
+	}
+	m.deleted[id] = true
+	return nil
+}
+
+// SetQuestionDisabled retire une question des quiz ou l'y remet
+func (m *MemoryStore) SetQuestionDisabled(id int, disabled bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.revisions[id]; !ok || m.deleted[id] {
+		return ErrQuestionNotFound
+	}
+	m.disabled[id] = disabled
+	return nil
+}
+
+// SaveReport enregistre le signalement d'une question par un joueur
+func (m *MemoryStore) SaveReport(r models.Report) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	r.ID = m.nextID
+	r.CreatedAt = time.Now()
+	r.Resolution = ""
+	r.ResolvedAt = time.Time{}
+	m.reports = append(m.reports, r)
+	return nil
+}
+
+// GetOpenReports récupère les signalements en attente, du plus ancien au plus récent
+func (m *MemoryStore) GetOpenReports() ([]models.Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var open []models.Report
+	for _, r := range m.reports {
+		if r.Resolution == "" {
+			open = append(open, r)
+		}
+	}
+	return open, nil
+}
+
+// CountOpenReports compte les signalements en attente de chaque question
+func (m *MemoryStore) CountOpenReports() (map[int]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[int]int)
+	for _, r := range m.reports {
+		if r.Resolution == "" {
+			counts[r.QuestionID]++
+		}
+	}
+	return counts, nil
+}
+
+// ResolveReports clôt tous les signalements en attente d'une question et retourne leur nombre
+func (m *MemoryStore) ResolveReports(questionID int, resolution string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resolved := 0
+	now := time.Now()
+	for i, r := range m.reports {
+		if r.QuestionID == questionID && r.Resolution == "" {
+			m.reports[i].Resolution = resolution
+			m.reports[i].ResolvedAt = now
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (m *MemoryStore) createQuestion(q models.Question) models.Question {
+	m.nextID++
+	q.ID = m.nextID
+	q.Revision = 1
+	q.Options = append([]string(nil), q.Options...)
+	q.Translations = copyTranslations(q.Translations)
+	m.revisions[q.ID] = []models.Question{q}
+	return q
+}
+
+// copyTranslations copie les traductions pour que la version enregistrée ne change plus
+func copyTranslations(translations map[string]models.Translation) map[string]models.Translation {
+	if translations == nil {
+		return nil
+	}
+	copied := make(map[string]models.Translation, len(translations))
+	for lang, t := range translations {
+		t.Options = append([]string(nil), t.Options...)
+		copied[lang] = t
+	}
+	return copied
+}
+
+// GetPreferences récupère les réglages d'un joueur (valeurs vides s'il n'en a jamais enregistré)
+func (m *MemoryStore) GetPreferences(username string) (models.Preferences, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if p, ok := m.preferences[username]; ok {
+		return p, nil
+	}
+	return models.Preferences{Username: username}, nil
+}
+
+// SavePreferences enregistre les réglages d'un joueur
+func (m *MemoryStore) SavePreferences(p models.Preferences) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p.UpdatedAt = time.Now()
+	m.preferences[p.Username] = p
+	return nil
+}
+
+// SaveProgress enregistre l'état d'une partie en cours (la crée si p.ID vaut 0)
+func (m *MemoryStore) SaveProgress(p models.Progress) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if p.ID == 0 {
+		m.nextID++
+		p.ID = m.nextID
+		p.StartedAt = now
+	} else if existing, ok := m.progress[p.ID]; !ok || existing.Status != models.AttemptInProgress {
+		return 0, ErrProgressAbandoned
+	} else {
+		p.StartedAt = existing.StartedAt
+	}
+	p.UpdatedAt = now
+	p.QuestionIDs = append([]int(nil), p.QuestionIDs...)
+	p.QuestionRevisions = append([]int(nil), p.QuestionRevisions...)
+	p.Answers = append([]models.Answer(nil), p.Answers...)
+	m.progress[p.ID] = p
+	return p.ID, nil
+}
+
+// GetOpenProgress récupère la dernière partie en cours d'un joueur, reconnu
+// à la fois par sa clé SSH et son pseudo
+func (m *MemoryStore) GetOpenProgress(identity, username string) (models.Progress, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest models.Progress
+	found := false
+	for _, p := range m.progress {
+		if p.Identity != identity || p.Username != username || p.Status != models.AttemptInProgress {
+			continue
+		}
+		if !found || p.UpdatedAt.After(latest.UpdatedAt) || (p.UpdatedAt.Equal(latest.UpdatedAt) && p.ID > latest.ID) {
+			latest, found = p, true
+		}
+	}
+	latest.QuestionIDs = append([]int(nil), latest.QuestionIDs...)
+	latest.QuestionRevisions = append([]int(nil), latest.QuestionRevisions...)
+	latest.Answers = append([]models.Answer(nil), latest.Answers...)
+	return latest, found, nil
+}
+
+// SetProgressStatus change l'état d'une partie en cours (terminée, abandonnée)
+func (m *MemoryStore) SetProgressStatus(id int, status models.AttemptStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.progress[id]
+	if !ok || p.Status != models.AttemptInProgress {
+		return ErrProgressAbandoned
+	}
+	p.Status = status
+	p.UpdatedAt = time.Now()
+	m.progress[id] = p
+	return nil
+}
+
+// AbandonStaleProgress marque comme abandonnées les parties en cours sans
+// réponse depuis before, sauf celles des sessions ouvertes (live)
+func (m *MemoryStore) AbandonStaleProgress(before time.Time, live []int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for id, p := range m.progress {
+		if p.Status == models.AttemptInProgress && p.UpdatedAt.Before(before) && !slices.Contains(live, id) {
+			p.Status = models.AttemptAbandoned
+			m.progress[id] = p
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Migrate n'a aucune migration à appliquer (le stockage en mémoire n'a pas de
+// schéma) mais importe les questions de seed dans une banque qui n'a jamais
+// eu de question, comme Database.Migrate
+func (m *MemoryStore) Migrate(seed Seed) (applied, imported int, err error) {
+	if seed == nil {
+		return 0, 0, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.revisions) > 0 {
+		return 0, 0, nil
+	}
+	questions := seed()
+	if err := validateImport(questions); err != nil {
+		return 0, 0, err
+	}
+	for _, q := range questions {
+		m.createQuestion(q)
+	}
+	return 0, len(questions), nil
+}
+
+// MigrationStatus ne retourne aucune migration pour le stockage en mémoire
+func (m *MemoryStore) MigrationStatus() ([]MigrationStatus, error) {
+	return nil, nil
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,721 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration est une évolution du schéma, appliquée une seule fois dans une transaction
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// MigrationStatus décrit l'état d'une migration pour la commande "migrate status"
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// execSQL crée une étape de migration qui exécute un script SQL
+func execSQL(script string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(script)
+		return err
+	}
+}
+
+// migrations liste toutes les évolutions du schéma, dans l'ordre d'application.
+// Ne jamais modifier une migration publiée: en ajouter une nouvelle à la fin.
+// Les premières utilisent IF NOT EXISTS car elles reprennent des tables créées
+// avant l'introduction des migrations.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create_scores",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS scores (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				score INTEGER NOT NULL,
+				total INTEGER NOT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_category ON scores(category);
+			CREATE INDEX IF NOT EXISTS idx_username ON scores(username);
+		`),
+	},
+	{
+		version: 2,
+		name:    "create_duels",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS duels (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				player1 TEXT NOT NULL,
+				player2 TEXT NOT NULL,
+				score1 INTEGER NOT NULL,
+				score2 INTEGER NOT NULL,
+				total INTEGER NOT NULL,
+				winner TEXT NOT NULL DEFAULT '',
+				forfeit INTEGER NOT NULL DEFAULT 0,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_duels_player1 ON duels(player1);
+			CREATE INDEX IF NOT EXISTS idx_duels_player2 ON duels(player2);
+		`),
+	},
+	{
+		version: 3,
+		name:    "create_ratings",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS ratings (
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				rating REAL NOT NULL,
+				games INTEGER NOT NULL DEFAULT 0,
+				updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (username, category)
+			);
+			CREATE INDEX IF NOT EXISTS idx_ratings_category ON ratings(category, rating);
+			CREATE TABLE IF NOT EXISTS rating_history (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				rating REAL NOT NULL,
+				delta REAL NOT NULL,
+				source TEXT NOT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_rating_history_user ON rating_history(username, category);
+		`),
+	},
+	{
+		version: 4,
+		name:    "scores_duration",
+		up: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "scores", "duration_ms", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		version: 5,
+		name:    "create_achievements",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS achievements (
+				username TEXT NOT NULL,
+				code TEXT NOT NULL,
+				unlocked_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (username, code)
+			);
+		`),
+	},
+	{
+		version: 6,
+		name:    "create_question_bank",
+		up: execSQL(`
+			CREATE TABLE categories (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE
+			);
+			CREATE TABLE questions (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				current_revision INTEGER NOT NULL DEFAULT 1,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				deleted_at DATETIME
+			);
+			CREATE TABLE question_revisions (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				question_id INTEGER NOT NULL REFERENCES questions(id),
+				revision INTEGER NOT NULL,
+				category_id INTEGER NOT NULL REFERENCES categories(id),
+				text TEXT NOT NULL,
+				answer INTEGER NOT NULL,
+				explanation TEXT NOT NULL DEFAULT '',
+				difficulty INTEGER NOT NULL DEFAULT 0,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (question_id, revision)
+			);
+			CREATE TABLE question_options (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				position INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				PRIMARY KEY (revision_id, position)
+			);
+			CREATE TABLE attempt_answers (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				score_id INTEGER NOT NULL REFERENCES scores(id),
+				question_id INTEGER NOT NULL,
+				revision INTEGER NOT NULL,
+				chosen INTEGER NOT NULL,
+				correct INTEGER NOT NULL,
+				duration_ms INTEGER NOT NULL DEFAULT 0
+			);
+			CREATE INDEX idx_attempt_answers_score ON attempt_answers(score_id);
+			CREATE INDEX idx_attempt_answers_question ON attempt_answers(question_id, revision);
+		`),
+	},
+	{
+		version: 7,
+		name:    "create_question_reports",
+		up: execSQL(`
+			ALTER TABLE questions ADD COLUMN disabled INTEGER NOT NULL DEFAULT 0;
+			CREATE TABLE question_reports (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				question_id INTEGER NOT NULL REFERENCES questions(id),
+				revision INTEGER NOT NULL,
+				username TEXT NOT NULL,
+				reason TEXT NOT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				resolution TEXT NOT NULL DEFAULT '',
+				resolved_at DATETIME
+			);
+			CREATE INDEX idx_question_reports_open ON question_reports(resolution, question_id);
+		`),
+	},
+	{
+		version: 8,
+		name:    "create_question_translations",
+		up: execSQL(`
+			ALTER TABLE question_revisions ADD COLUMN lang TEXT NOT NULL DEFAULT '';
+			CREATE TABLE question_translations (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				lang TEXT NOT NULL,
+				text TEXT NOT NULL,
+				explanation TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (revision_id, lang)
+			);
+			CREATE TABLE question_option_translations (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				lang TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				PRIMARY KEY (revision_id, lang, position)
+			);
+		`),
+	},
+	{
+		version: 9,
+		name:    "create_user_preferences",
+		up: execSQL(`
+			CREATE TABLE user_preferences (
+				username TEXT PRIMARY KEY,
+				lang TEXT NOT NULL DEFAULT '',
+				updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+		`),
+	},
+	{
+		version: 10,
+		name:    "add_user_preferences_theme",
+		up: execSQL(`
+			ALTER TABLE user_preferences ADD COLUMN theme TEXT NOT NULL DEFAULT '';
+		`),
+	},
+	{
+		version: 11,
+		name:    "add_user_preferences_answers",
+		up: execSQL(`
+			ALTER TABLE user_preferences ADD COLUMN confirm_answers INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE user_preferences ADD COLUMN mouse INTEGER NOT NULL DEFAULT 0;
+		`),
+	},
+	{
+		version: 12,
+		name:    "create_quiz_progress",
+		up: execSQL(`
+			CREATE TABLE quiz_progress (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				seed INTEGER NOT NULL,
+				question_ids TEXT NOT NULL,
+				current_index INTEGER NOT NULL DEFAULT 0,
+				status TEXT NOT NULL DEFAULT 'in_progress',
+				started_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX idx_quiz_progress_user ON quiz_progress(username, status);
+			CREATE TABLE quiz_progress_answers (
+				progress_id INTEGER NOT NULL REFERENCES quiz_progress(id),
+				position INTEGER NOT NULL,
+				question_id INTEGER NOT NULL,
+				revision INTEGER NOT NULL,
+				chosen INTEGER NOT NULL,
+				correct INTEGER NOT NULL,
+				duration_ms INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (progress_id, position)
+			);
+		`),
+	},
+	{
+		version: 13,
+		name:    "drop_duel_category_ratings",
+		// Les duels tenaient aussi un niveau sous la pseudo-catégorie "Duel"
+		up: execSQL(`
+			DELETE FROM rating_history WHERE category = 'Duel';
+			DELETE FROM ratings WHERE category = 'Duel';
+		`),
+	},
+	{
+		version: 14,
+		name:    "add_quiz_progress_identity",
+		// Une partie ne se reprend qu'avec la clé SSH qui l'a commencée, et avec
+		// les versions des questions posées; les parties déjà en cours ne sont
+		// donc plus reprenables
+		up: execSQL(`
+			ALTER TABLE quiz_progress ADD COLUMN identity TEXT NOT NULL DEFAULT '';
+			ALTER TABLE quiz_progress ADD COLUMN revisions TEXT NOT NULL DEFAULT '';
+			DROP INDEX idx_quiz_progress_user;
+			CREATE INDEX idx_quiz_progress_identity ON quiz_progress(identity, username, status);
+		`),
+	},
+}
+
+// postgresMigrations reprend les mêmes versions que migrations avec les types PostgreSQL.
+// Toute nouvelle migration doit être ajoutée aux deux listes sous le même numéro.
+var postgresMigrations = []migration{
+	{
+		version: 1,
+		name:    "create_scores",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS scores (
+				id SERIAL PRIMARY KEY,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				score INTEGER NOT NULL,
+				total INTEGER NOT NULL,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_category ON scores(category);
+			CREATE INDEX IF NOT EXISTS idx_username ON scores(username);
+		`),
+	},
+	{
+		version: 2,
+		name:    "create_duels",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS duels (
+				id SERIAL PRIMARY KEY,
+				player1 TEXT NOT NULL,
+				player2 TEXT NOT NULL,
+				score1 INTEGER NOT NULL,
+				score2 INTEGER NOT NULL,
+				total INTEGER NOT NULL,
+				winner TEXT NOT NULL DEFAULT '',
+				forfeit BOOLEAN NOT NULL DEFAULT FALSE,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_duels_player1 ON duels(player1);
+			CREATE INDEX IF NOT EXISTS idx_duels_player2 ON duels(player2);
+		`),
+	},
+	{
+		version: 3,
+		name:    "create_ratings",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS ratings (
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				rating DOUBLE PRECISION NOT NULL,
+				games INTEGER NOT NULL DEFAULT 0,
+				updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (username, category)
+			);
+			CREATE INDEX IF NOT EXISTS idx_ratings_category ON ratings(category, rating);
+			CREATE TABLE IF NOT EXISTS rating_history (
+				id SERIAL PRIMARY KEY,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				rating DOUBLE PRECISION NOT NULL,
+				delta DOUBLE PRECISION NOT NULL,
+				source TEXT NOT NULL,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_rating_history_user ON rating_history(username, category);
+		`),
+	},
+	{
+		version: 4,
+		name:    "scores_duration",
+		up:      execSQL(`ALTER TABLE scores ADD COLUMN IF NOT EXISTS duration_ms BIGINT NOT NULL DEFAULT 0`),
+	},
+	{
+		version: 5,
+		name:    "create_achievements",
+		up: execSQL(`
+			CREATE TABLE IF NOT EXISTS achievements (
+				username TEXT NOT NULL,
+				code TEXT NOT NULL,
+				unlocked_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (username, code)
+			);
+		`),
+	},
+	{
+		version: 6,
+		name:    "create_question_bank",
+		up: execSQL(`
+			CREATE TABLE categories (
+				id SERIAL PRIMARY KEY,
+				name TEXT NOT NULL UNIQUE
+			);
+			CREATE TABLE questions (
+				id SERIAL PRIMARY KEY,
+				current_revision INTEGER NOT NULL DEFAULT 1,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				deleted_at TIMESTAMPTZ
+			);
+			CREATE TABLE question_revisions (
+				id SERIAL PRIMARY KEY,
+				question_id INTEGER NOT NULL REFERENCES questions(id),
+				revision INTEGER NOT NULL,
+				category_id INTEGER NOT NULL REFERENCES categories(id),
+				text TEXT NOT NULL,
+				answer INTEGER NOT NULL,
+				explanation TEXT NOT NULL DEFAULT '',
+				difficulty INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (question_id, revision)
+			);
+			CREATE TABLE question_options (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				position INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				PRIMARY KEY (revision_id, position)
+			);
+			CREATE TABLE attempt_answers (
+				id SERIAL PRIMARY KEY,
+				score_id INTEGER NOT NULL REFERENCES scores(id),
+				question_id INTEGER NOT NULL,
+				revision INTEGER NOT NULL,
+				chosen INTEGER NOT NULL,
+				correct BOOLEAN NOT NULL,
+				duration_ms BIGINT NOT NULL DEFAULT 0
+			);
+			CREATE INDEX idx_attempt_answers_score ON attempt_answers(score_id);
+			CREATE INDEX idx_attempt_answers_question ON attempt_answers(question_id, revision);
+		`),
+	},
+	{
+		version: 7,
+		name:    "create_question_reports",
+		up: execSQL(`
+			ALTER TABLE questions ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT FALSE;
+			CREATE TABLE question_reports (
+				id SERIAL PRIMARY KEY,
+				question_id INTEGER NOT NULL REFERENCES questions(id),
+				revision INTEGER NOT NULL,
+				username TEXT NOT NULL,
+				reason TEXT NOT NULL,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				resolution TEXT NOT NULL DEFAULT '',
+				resolved_at TIMESTAMPTZ
+			);
+			CREATE INDEX idx_question_reports_open ON question_reports(resolution, question_id);
+		`),
+	},
+	{
+		version: 8,
+		name:    "create_question_translations",
+		up: execSQL(`
+			ALTER TABLE question_revisions ADD COLUMN lang TEXT NOT NULL DEFAULT '';
+			CREATE TABLE question_translations (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				lang TEXT NOT NULL,
+				text TEXT NOT NULL,
+				explanation TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (revision_id, lang)
+			);
+			CREATE TABLE question_option_translations (
+				revision_id INTEGER NOT NULL REFERENCES question_revisions(id),
+				lang TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				PRIMARY KEY (revision_id, lang, position)
+			);
+		`),
+	},
+	{
+		version: 9,
+		name:    "create_user_preferences",
+		up: execSQL(`
+			CREATE TABLE user_preferences (
+				username TEXT PRIMARY KEY,
+				lang TEXT NOT NULL DEFAULT '',
+				updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+			);
+		`),
+	},
+	{
+		version: 10,
+		name:    "add_user_preferences_theme",
+		up: execSQL(`
+			ALTER TABLE user_preferences ADD COLUMN theme TEXT NOT NULL DEFAULT '';
+		`),
+	},
+	{
+		version: 11,
+		name:    "add_user_preferences_answers",
+		up: execSQL(`
+			ALTER TABLE user_preferences ADD COLUMN confirm_answers BOOLEAN NOT NULL DEFAULT FALSE;
+			ALTER TABLE user_preferences ADD COLUMN mouse BOOLEAN NOT NULL DEFAULT FALSE;
+		`),
+	},
+	{
+		version: 12,
+		name:    "create_quiz_progress",
+		up: execSQL(`
+			CREATE TABLE quiz_progress (
+				id SERIAL PRIMARY KEY,
+				username TEXT NOT NULL,
+				category TEXT NOT NULL,
+				seed BIGINT NOT NULL,
+				question_ids TEXT NOT NULL,
+				current_index INTEGER NOT NULL DEFAULT 0,
+				status TEXT NOT NULL DEFAULT 'in_progress',
+				started_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX idx_quiz_progress_user ON quiz_progress(username, status);
+			CREATE TABLE quiz_progress_answers (
+				progress_id INTEGER NOT NULL REFERENCES quiz_progress(id),
+				position INTEGER NOT NULL,
+				question_id INTEGER NOT NULL,
+				revision INTEGER NOT NULL,
+				chosen INTEGER NOT NULL,
+				correct BOOLEAN NOT NULL,
+				duration_ms BIGINT NOT NULL DEFAULT 0,
+				PRIMARY KEY (progress_id, position)
+			);
+		`),
+	},
+	{
+		version: 13,
+		name:    "drop_duel_category_ratings",
+		// Les duels tenaient aussi un niveau sous la pseudo-catégorie "Duel"
+		up: execSQL(`
+			DELETE FROM rating_history WHERE category = 'Duel';
+			DELETE FROM ratings WHERE category = 'Duel';
+		`),
+	},
+	{
+		version: 14,
+		name:    "add_quiz_progress_identity",
+		// Une partie ne se reprend qu'avec la clé SSH qui l'a commencée, et avec
+		// les versions des questions posées; les parties déjà en cours ne sont
+		// donc plus reprenables
+		up: execSQL(`
+			ALTER TABLE quiz_progress ADD COLUMN identity TEXT NOT NULL DEFAULT '';
+			ALTER TABLE quiz_progress ADD COLUMN revisions TEXT NOT NULL DEFAULT '';
+			DROP INDEX idx_quiz_progress_user;
+			CREATE INDEX idx_quiz_progress_identity ON quiz_progress(identity, username, status);
+		`),
+	},
+}
+
+// migrations retourne la liste des migrations correspondant au moteur de la base
+func (d *Database) migrations() []migration {
+	if d.dialect == dialectPostgres {
+		return postgresMigrations
+	}
+	return migrations
+}
+
+// execer et queryer sont satisfaits par *sql.DB comme par *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// ensureMigrationsTable crée la table de suivi des migrations
+func (d *Database) ensureMigrationsTable(db execer) error {
+	timestamp := "DATETIME"
+	if d.dialect == dialectPostgres {
+		timestamp = "TIMESTAMPTZ"
+	}
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at ` + timestamp + ` DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	return err
+}
+
+// appliedMigrations retourne la date d'application de chaque version déjà appliquée
+func (d *Database) appliedMigrations(db queryer) (map[int]time.Time, error) {
+	rows, err := db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// schemaLockKey identifie le verrou consultatif PostgreSQL des migrations
+const schemaLockKey = 0x7175697a // "quiz"
+
+// lockSchema sérialise, jusqu'à la fin de tx, les migrations et l'import
+// initial des instances qui partagent la base, et crée la table de suivi
+func (d *Database) lockSchema(tx *sql.Tx) error {
+	if d.dialect == dialectPostgres {
+		// La seconde instance attend ici la fin de la première. Le verrou précède
+		// CREATE TABLE IF NOT EXISTS, qui échoue en cas de création simultanée.
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", schemaLockKey); err != nil {
+			return err
+		}
+		return d.ensureMigrationsTable(tx)
+	}
+
+	// SQLite: une écriture en tout début de transaction prend le verrou
+	// d'écriture de la base, en attendant qu'il se libère
+	if err := d.ensureMigrationsTable(d.db); err != nil {
+		return err
+	}
+	_, err := tx.Exec("DELETE FROM schema_migrations WHERE version < 0")
+	return err
+}
+
+// Migrate applique les migrations en attente puis, si la banque de questions
+// est vide et seed non nil, y importe les questions de seed. Le tout se fait
+// dans une seule transaction, sous un verrou: plusieurs instances qui
+// démarrent ensemble sur la même base ne migrent et n'importent qu'une fois.
+func (d *Database) Migrate(seed Seed) (applied, imported int, err error) {
+	return d.migrate(d.migrations(), seed)
+}
+
+// migrate applique les migrations de known qui ne le sont pas encore
+func (d *Database) migrate(known []migration, seed Seed) (applied, imported int, err error) {
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rollback()
+
+	if err := d.lockSchema(tx); err != nil {
+		return 0, 0, err
+	}
+	done, err := d.appliedMigrations(tx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, m := range known {
+		if _, ok := done[m.version]; ok {
+			continue
+		}
+		if err := d.applyMigration(tx, m); err != nil {
+			return 0, 0, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+		applied++
+	}
+
+	if seed != nil {
+		if imported, err = d.seedQuestions(tx, seed); err != nil {
+			return 0, 0, fmt.Errorf("import initial des questions: %w", err)
+		}
+	}
+	return applied, imported, tx.Commit()
+}
+
+func (d *Database) applyMigration(tx *sql.Tx, m migration) error {
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	_, err := tx.Exec(d.dialect.rebind(
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)"),
+		m.version, m.name, time.Now(),
+	)
+	return err
+}
+
+// seedQuestions importe les questions de seed si la banque n'a jamais eu de
+// question: les questions supprimées comptent, une banque vidée par un
+// administrateur ne doit pas être réimportée au redémarrage suivant
+func (d *Database) seedQuestions(tx *sql.Tx, seed Seed) (int, error) {
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM questions").Scan(&count); err != nil || count > 0 {
+		return 0, err
+	}
+	questions := seed()
+	if err := validateImport(questions); err != nil {
+		return 0, err
+	}
+	for _, q := range questions {
+		if _, err := d.createQuestion(tx, q); err != nil {
+			return 0, err
+		}
+	}
+	return len(questions), nil
+}
+
+// MigrationStatus liste toutes les migrations connues et indique si elles sont appliquées
+func (d *Database) MigrationStatus() ([]MigrationStatus, error) {
+	if err := d.ensureMigrationsTable(d.db); err != nil {
+		return nil, err
+	}
+	applied, err := d.appliedMigrations(d.db)
+	if err != nil {
+		return nil, err
+	}
+
+	known := d.migrations()
+	statuses := make([]MigrationStatus, len(known))
+	for i, m := range known {
+		appliedAt, ok := applied[m.version]
+		statuses[i] = MigrationStatus{
+			Version:   m.version,
+			Name:      m.name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+	return statuses, nil
+}
+
+// addColumnIfMissing ajoute une colonne à une table existante si elle n'y est pas encore
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		if name == column {
+			found = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || found {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"path/filepath"
+	"quizz-ssh/models"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// seedBank retourne une Seed de n questions qui compte ses appels
+func seedBank(n int, calls *atomic.Int32) Seed {
+	return func() []models.Question {
+		calls.Add(1)
+		questions := make([]models.Question, n)
+		for i := range questions {
+			questions[i] = models.Question{
+				Category: "Réseau",
+				Text:     "Question " + string(rune('A'+i)),
+				Options:  []string{"Oui", "Non"},
+			}
+		}
+		return questions
+	}
+}
+
+// checkFullyMigrated vérifie que toutes les migrations connues sont appliquées
+func checkFullyMigrated(t *testing.T, d *Database) {
+	t.Helper()
+	statuses, err := d.MigrationStatus()
+	if err != nil {
+		t.Fatalf("MigrationStatus: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Errorf("migration %d (%s) non appliquée", s.Version, s.Name)
+		}
+	}
+}
+
+// Une base arrêtée à n'importe quelle version se met à jour sans perdre ses parties
+func TestMigrateFromEachVersion(t *testing.T) {
+	for _, engine := range testEngines() {
+		known := openRawTestDatabase(t, engine).migrations()
+		for v := 0; v <= len(known); v++ {
+			t.Run(engine+"/"+known[max(v-1, 0)].name, func(t *testing.T) {
+				d := openRawTestDatabase(t, engine)
+				if _, _, err := d.migrate(known[:v], nil); err != nil {
+					t.Fatalf("migration jusqu'à la version %d: %v", v, err)
+				}
+				if v > 0 {
+					// Colonnes de la toute première version de la table
+					_, err := d.db.Exec(d.dialect.rebind(
+						"INSERT INTO scores (username, category, score, total) VALUES (?, ?, ?, ?)"),
+						"alice", "Réseau", 7, 10)
+					if err != nil {
+						t.Fatalf("insertion d'une partie: %v", err)
+					}
+				}
+
+				applied, _, err := d.Migrate(nil)
+				if err != nil {
+					t.Fatalf("Migrate: %v", err)
+				}
+				if applied != len(known)-v {
+					t.Errorf("%d migration(s) appliquée(s), attendu %d", applied, len(known)-v)
+				}
+				checkFullyMigrated(t, d)
+
+				games, _, err := d.GetStats()
+				if err != nil {
+					t.Fatalf("GetStats: %v", err)
+				}
+				if want := min(v, 1); games != want {
+					t.Errorf("%d partie(s) après migration, attendu %d", games, want)
+				}
+			})
+		}
+	}
+}
+
+// Une base créée avant l'introduction des migrations n'a que la table scores
+func TestMigrateLegacySchema(t *testing.T) {
+	d := openRawTestDatabase(t, "sqlite")
+	_, err := d.db.Exec(`
+		CREATE TABLE scores (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL,
+			category TEXT NOT NULL,
+			score INTEGER NOT NULL,
+			total INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO scores (username, category, score, total) VALUES ('alice', 'Réseau', 7, 10);
+	`)
+	if err != nil {
+		t.Fatalf("schéma historique: %v", err)
+	}
+
+	if _, _, err := d.Migrate(nil); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	checkFullyMigrated(t, d)
+	best, err := d.GetUserBestScore("alice", "Réseau")
+	if err != nil {
+		t.Fatalf("GetUserBestScore: %v", err)
+	}
+	if best != 7 {
+		t.Errorf("meilleur score %d, attendu 7", best)
+	}
+}
+
+func TestMigrateIdempotent(t *testing.T) {
+	for _, engine := range testEngines() {
+		t.Run(engine, func(t *testing.T) {
+			d := openRawTestDatabase(t, engine)
+			if _, _, err := d.Migrate(nil); err != nil {
+				t.Fatalf("Migrate: %v", err)
+			}
+			applied, imported, err := d.Migrate(nil)
+			if err != nil {
+				t.Fatalf("second Migrate: %v", err)
+			}
+			if applied != 0 || imported != 0 {
+				t.Errorf("second Migrate: %d migration(s), %d question(s), attendu aucune", applied, imported)
+			}
+		})
+	}
+}
+
+// Plusieurs instances qui démarrent ensemble sur la même base ne migrent et
+// n'importent la banque initiale qu'une seule fois
+func TestMigrateConcurrentInstances(t *testing.T) {
+	const instances = 5
+	path := filepath.Join(t.TempDir(), "quiz.db")
+	var calls atomic.Int32
+	seed := seedBank(3, &calls)
+
+	var wg sync.WaitGroup
+	results := make([]struct{ applied, imported int }, instances)
+	errs := make([]error, instances)
+	for i := range instances {
+		d, err := OpenDatabase(path)
+		if err != nil {
+			t.Fatalf("OpenDatabase: %v", err)
+		}
+		t.Cleanup(func() { d.Close() })
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].applied, results[i].imported, errs[i] = d.Migrate(seed)
+		}()
+	}
+	wg.Wait()
+
+	applied, imported := 0, 0
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("instance %d: Migrate: %v", i, err)
+		}
+		applied += results[i].applied
+		imported += results[i].imported
+	}
+	if want := len(migrations); applied != want {
+		t.Errorf("%d migration(s) appliquée(s) au total, attendu %d", applied, want)
+	}
+	if imported != 3 || calls.Load() != 1 {
+		t.Errorf("%d question(s) importée(s) en %d appel(s), attendu 3 en 1", imported, calls.Load())
+	}
+
+	d, err := OpenDatabase(path)
+	if err != nil {
+		t.Fatalf("OpenDatabase: %v", err)
+	}
+	defer d.Close()
+	if count, err := d.CountQuestions(); err != nil || count != 3 {
+		t.Errorf("CountQuestions() = %d, %v, attendu 3", count, err)
+	}
+}
+
+// La banque initiale n'est importée qu'une fois, même si un administrateur
+// supprime ensuite toutes les questions
+func TestMigrateSeed(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			_, imported, err := store.Migrate(seedBank(2, &calls))
+			if err != nil {
+				t.Fatalf("Migrate: %v", err)
+			}
+			if imported != 2 {
+				t.Errorf("%d question(s) importée(s), attendu 2", imported)
+			}
+
+			_, imported, err = store.Migrate(seedBank(2, &calls))
+			if err != nil {
+				t.Fatalf("second Migrate: %v", err)
+			}
+			if imported != 0 || calls.Load() != 1 {
+				t.Errorf("banque non vide: %d question(s) importée(s) en %d appel(s), attendu 0 en 1", imported, calls.Load())
+			}
+
+			questions, err := store.GetQuestions()
+			if err != nil {
+				t.Fatalf("GetQuestions: %v", err)
+			}
+			for _, q := range questions {
+				if err := store.DeleteQuestion(q.ID); err != nil {
+					t.Fatalf("DeleteQuestion: %v", err)
+				}
+			}
+			_, imported, err = store.Migrate(seedBank(2, &calls))
+			if err != nil {
+				t.Fatalf("Migrate après suppression: %v", err)
+			}
+			if imported != 0 || calls.Load() != 1 {
+				t.Errorf("banque vidée: %d question(s) réimportée(s) en %d appel(s), attendu 0 en 1", imported, calls.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"quizz-ssh/models"
+	"time"
+)
+
+// GetPreferences récupère les réglages d'un joueur (valeurs vides s'il n'en a jamais enregistré)
+func (d *Database) GetPreferences(username string) (models.Preferences, error) {
+	p := models.Preferences{Username: username}
+	err := d.queryRow(
+		"SELECT lang, theme, confirm_answers, mouse, updated_at FROM user_preferences WHERE username = ?", username,
+	).Scan(&p.Lang, &p.Theme, &p.ConfirmAnswers, &p.Mouse, &p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, nil
+	}
+	return p, err
+}
+
+// SavePreferences enregistre les réglages d'un joueur
+func (d *Database) SavePreferences(p models.Preferences) error {
+	_, err := d.exec(`
+		INSERT INTO user_preferences (username, lang, theme, confirm_answers, mouse, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(username) DO UPDATE SET
+			lang = excluded.lang,
+			theme = excluded.theme,
+			confirm_answers = excluded.confirm_answers,
+			mouse = excluded.mouse,
+			updated_at = excluded.updated_at
+	`, p.Username, p.Lang, p.Theme, p.ConfirmAnswers, p.Mouse, time.Now())
+	return err
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"database/sql"
+	"quizz-ssh/models"
+	"time"
+)
+
+// GetUserStats récupère les statistiques cumulées d'un joueur
+func (d *Database) GetUserStats(username string) (models.UserStats, error) {
+	var stats models.UserStats
+	var totalMs int64
+	var first, last sql.NullString
+	err := d.queryRow(`
+		SELECT COUNT(*), COALESCE(SUM(score), 0), COALESCE(SUM(total), 0), COALESCE(SUM(duration_ms), 0),
+			MIN(created_at), MAX(created_at)
+		FROM scores
+		WHERE username = ?
+	`, username).Scan(&stats.Attempts, &stats.Correct, &stats.Questions, &totalMs, &first, &last)
+	if err != nil {
+		return stats, err
+	}
+	stats.TotalTime = time.Duration(totalMs) * time.Millisecond
+	stats.FirstPlayed = parseTime(first.String)
+	stats.LastPlayed = parseTime(last.String)
+	return stats, nil
+}
+
+// GetUserHistory récupère les dernières parties d'un joueur, de la plus récente à la plus ancienne
+func (d *Database) GetUserHistory(username string, limit int) ([]models.Score, error) {
+	rows, err := d.query(`
+		SELECT id, username, category, score, total, duration_ms, created_at
+		FROM scores
+		WHERE username = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, username, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.Score
+	for rows.Next() {
+		var score models.Score
+		var durationMs int64
+		var createdAtStr string
+		if err := rows.Scan(&score.ID, &score.Username, &score.Category, &score.Score, &score.Total, &durationMs, &createdAtStr); err != nil {
+			return nil, err
+		}
+		score.Duration = time.Duration(durationMs) * time.Millisecond
+		score.CreatedAt = parseTime(createdAtStr)
+		scores = append(scores, score)
+	}
+	return scores, rows.Err()
+}
+
+// GetUserBestScores récupère la meilleure partie d'un joueur dans chaque catégorie
+func (d *Database) GetUserBestScores(username string) ([]models.Score, error) {
+	rows, err := d.query(`
+		WITH ranked AS (
+			SELECT id, username, category, score, total, duration_ms, created_at,
+				ROW_NUMBER() OVER (
+					PARTITION BY category
+					ORDER BY CAST(score AS REAL) / NULLIF(total, 0) DESC, duration_ms ASC, created_at ASC, id ASC
+				) AS attempt_rank
+			FROM scores
+			WHERE username = ?
+		)
+		SELECT id, username, category, score, total, duration_ms, created_at
+		FROM ranked
+		WHERE attempt_rank = 1
+		ORDER BY category
+	`, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.Score
+	for rows.Next() {
+		var score models.Score
+		var durationMs int64
+		var createdAtStr string
+		if err := rows.Scan(&score.ID, &score.Username, &score.Category, &score.Score, &score.Total, &durationMs, &createdAtStr); err != nil {
+			return nil, err
+		}
+		score.Duration = time.Duration(durationMs) * time.Millisecond
+		score.CreatedAt = parseTime(createdAtStr)
+		scores = append(scores, score)
+	}
+	return scores, rows.Err()
+}
+
+// GetUserCategoryRates calcule, pour chaque catégorie de question, le meilleur
+// taux de bonnes réponses d'un joueur sur une partie (catégorie de la version
+// de la question posée)
+func (d *Database) GetUserCategoryRates(username string) (map[string]float64, error) {
+	rows, err := d.query(`
+		WITH per_attempt AS (
+			SELECT c.name AS category,
+				AVG(CASE WHEN a.correct THEN 1.0 ELSE 0.0 END) AS rate
+			FROM attempt_answers a
+			JOIN scores s ON s.id = a.score_id
+			JOIN question_revisions r ON r.question_id = a.question_id AND r.revision = a.revision
+			JOIN categories c ON c.id = r.category_id
+			WHERE s.username = ?
+			GROUP BY c.name, a.score_id
+		)
+		SELECT category, MAX(rate) FROM per_attempt GROUP BY category
+	`, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := make(map[string]float64)
+	for rows.Next() {
+		var category string
+		var rate float64
+		if err := rows.Scan(&category, &rate); err != nil {
+			return nil, err
+		}
+		rates[category] = rate
+	}
+	return rates, rows.Err()
+}
+
+// GetProfile assemble le profil complet d'un joueur, quel que soit le stockage
+func GetProfile(d Store, username string, historyLimit int) (models.Profile, error) {
+	profile := models.Profile{Username: username}
+	var err error
+
+	if profile.Stats, err = d.GetUserStats(username); err != nil {
+		return profile, err
+	}
+	if profile.Best, err = d.GetUserBestScores(username); err != nil {
+		return profile, err
+	}
+	if profile.History, err = d.GetUserHistory(username, historyLimit); err != nil {
+		return profile, err
+	}
+	if profile.Duels, err = d.GetDuelRecord(username); err != nil {
+		return profile, err
+	}
+	if profile.Rating, err = d.GetRating(username, "global"); err != nil {
+		return profile, err
+	}
+	if profile.Achievements, err = d.GetUserAchievements(username); err != nil {
+		return profile, err
+	}
+	return profile, nil
+}
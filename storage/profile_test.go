@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"quizz-ssh/achievements"
+	"quizz-ssh/models"
+	"testing"
+)
+
+// importTestQuestions importe une banque de deux questions par catégorie
+func importTestQuestions(t *testing.T, store Store) []models.Question {
+	t.Helper()
+	var bank []models.Question
+	for _, category := range []string{"Cryptographie", "Réseau"} {
+		for _, text := range []string{"Première question", "Seconde question"} {
+			bank = append(bank, models.Question{
+				Category: category,
+				Text:     category + ": " + text,
+				Options:  []string{"Oui", "Non"},
+				Answer:   0,
+			})
+		}
+	}
+	if _, err := store.ImportQuestions(bank); err != nil {
+		t.Fatalf("ImportQuestions: %v", err)
+	}
+	questions, err := store.GetQuestions()
+	if err != nil {
+		t.Fatalf("GetQuestions: %v", err)
+	}
+	return questions
+}
+
+// playAttempt enregistre une partie de username sur toutes les questions;
+// correct indique, par catégorie, combien de réponses sont justes
+func playAttempt(t *testing.T, store Store, username string, questions []models.Question, correct map[string]int) {
+	t.Helper()
+	var answers []models.Answer
+	seen := make(map[string]int)
+	score := 0
+	for _, q := range questions {
+		ok := seen[q.Category] < correct[q.Category]
+		seen[q.Category]++
+		if ok {
+			score++
+		}
+		answers = append(answers, models.Answer{QuestionID: q.ID, Revision: q.Revision, Correct: ok})
+	}
+	s := models.Score{Username: username, Category: "Cybersecurity", Score: score, Total: len(questions)}
+	if _, err := store.SaveAttempt(s, answers); err != nil {
+		t.Fatalf("SaveAttempt: %v", err)
+	}
+}
+
+func TestGetUserCategoryRates(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			questions := importTestQuestions(t, store)
+			playAttempt(t, store, "alice", questions, map[string]int{"Cryptographie": 2, "Réseau": 0})
+			playAttempt(t, store, "alice", questions, map[string]int{"Cryptographie": 1, "Réseau": 1})
+			playAttempt(t, store, "bob", questions, map[string]int{"Réseau": 2})
+
+			rates, err := store.GetUserCategoryRates("alice")
+			if err != nil {
+				t.Fatalf("GetUserCategoryRates: %v", err)
+			}
+			want := map[string]float64{"Cryptographie": 1, "Réseau": 0.5}
+			if len(rates) != len(want) {
+				t.Errorf("taux %v, attendu %v", rates, want)
+			}
+			for category, rate := range want {
+				if rates[category] != rate {
+					t.Errorf("%s: taux %v, attendu %v", category, rates[category], rate)
+				}
+			}
+		})
+	}
+}
+
+// Le badge "Polyvalent" se débloque dès que la banque compte plusieurs
+// catégories, bien que les parties solo soient enregistrées sous une seule
+func TestAllCategoriesAchievementUnlocks(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			questions := importTestQuestions(t, store)
+			playAttempt(t, store, "alice", questions, map[string]int{"Cryptographie": 2, "Réseau": 2})
+
+			rates, err := store.GetUserCategoryRates("alice")
+			if err != nil {
+				t.Fatalf("GetUserCategoryRates: %v", err)
+			}
+			ctx := achievements.Context{
+				Score:         models.Score{Score: 3, Total: 4},
+				Categories:    GetUniqueCategories(questions),
+				CategoryRates: rates,
+			}
+			for _, a := range achievements.Evaluate(ctx, nil) {
+				if a.Code == "all_categories_80" {
+					return
+				}
+			}
+			t.Errorf("badge all_categories_80 non débloqué (catégories %v, taux %v)", ctx.Categories, rates)
+		})
+	}
+}
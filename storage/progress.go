@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"quizz-ssh/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrProgressAbandoned est retournée pour une partie qui n'est plus en cours:
+// abandonnée entre-temps, par exemple après l'expiration du délai de reprise
+var ErrProgressAbandoned = errors.New("partie abandonnée")
+
+// SaveProgress enregistre l'état d'une partie en cours (la crée si p.ID vaut 0)
+// avec toutes les réponses déjà données, et retourne son identifiant
+func (d *Database) SaveProgress(p models.Progress) (int, error) {
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer rollback()
+
+	now := time.Now()
+	id := p.ID
+	if id == 0 {
+		id, err = d.dialect.insert(tx,
+			"INSERT INTO quiz_progress (identity, username, category, seed, question_ids, revisions, current_index, status, started_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			p.Identity, p.Username, p.Category, p.Seed, joinIDs(p.QuestionIDs), joinIDs(p.QuestionRevisions), p.CurrentIndex, p.Status, now, now,
+		)
+		if err != nil {
+			return 0, err
+		}
+	} else if err := d.updateOpenProgress(tx,
+		"current_index = ?, status = ?, updated_at = ?", id, p.CurrentIndex, p.Status, now); err != nil {
+		return 0, err
+	}
+
+	// Les réponses sont réécrites en entier: une partie en compte au plus quelques dizaines
+	if _, err := tx.Exec(d.dialect.rebind("DELETE FROM quiz_progress_answers WHERE progress_id = ?"), id); err != nil {
+		return 0, err
+	}
+	for i, a := range p.Answers {
+		_, err := tx.Exec(d.dialect.rebind(
+			"INSERT INTO quiz_progress_answers (progress_id, position, question_id, revision, chosen, correct, duration_ms) VALUES (?, ?, ?, ?, ?, ?, ?)"),
+			id, i, a.QuestionID, a.Revision, a.Chosen, a.Correct, a.Duration.Milliseconds(),
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+// updateOpenProgress modifie (set) la partie id si elle est encore en cours,
+// et retourne ErrProgressAbandoned sinon
+func (d *Database) updateOpenProgress(db execer, set string, id int, args ...any) error {
+	res, err := db.Exec(d.dialect.rebind("UPDATE quiz_progress SET "+set+" WHERE id = ? AND status = ?"),
+		append(args, id, models.AttemptInProgress)...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrProgressAbandoned
+	}
+	return nil
+}
+
+// GetOpenProgress récupère la dernière partie en cours d'un joueur, reconnu
+// à la fois par sa clé SSH (identity) et son pseudo; ok vaut false s'il n'en
+// a aucune
+func (d *Database) GetOpenProgress(identity, username string) (p models.Progress, ok bool, err error) {
+	var ids, revisions string
+	err = d.queryRow(`
+		SELECT id, identity, username, category, seed, question_ids, revisions, current_index, status, started_at, updated_at
+		FROM quiz_progress
+		WHERE identity = ? AND username = ? AND status = ?
+		ORDER BY updated_at DESC, id DESC
+		LIMIT 1
+	`, identity, username, models.AttemptInProgress).Scan(
+		&p.ID, &p.Identity, &p.Username, &p.Category, &p.Seed, &ids, &revisions, &p.CurrentIndex, &p.Status, &p.StartedAt, &p.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, false, nil
+	}
+	if err != nil {
+		return p, false, err
+	}
+	if p.QuestionIDs, err = splitIDs(ids); err != nil {
+		return p, false, err
+	}
+	if p.QuestionRevisions, err = splitIDs(revisions); err != nil {
+		return p, false, err
+	}
+
+	rows, err := d.query(`
+		SELECT question_id, revision, chosen, correct, duration_ms
+		FROM quiz_progress_answers
+		WHERE progress_id = ?
+		ORDER BY position
+	`, p.ID)
+	if err != nil {
+		return p, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.Answer
+		var durationMs int64
+		if err := rows.Scan(&a.QuestionID, &a.Revision, &a.Chosen, &a.Correct, &durationMs); err != nil {
+			return p, false, err
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		p.Answers = append(p.Answers, a)
+	}
+	return p, true, rows.Err()
+}
+
+// SetProgressStatus change l'état d'une partie en cours (terminée, abandonnée);
+// ErrProgressAbandoned si elle n'était déjà plus en cours
+func (d *Database) SetProgressStatus(id int, status models.AttemptStatus) error {
+	defer observe("exec", time.Now())
+	return d.updateOpenProgress(d.db, "status = ?, updated_at = ?", id, status, time.Now())
+}
+
+// AbandonStaleProgress marque comme abandonnées les parties en cours sans
+// réponse depuis before, sauf celles des sessions ouvertes (live), et
+// retourne leur nombre
+func (d *Database) AbandonStaleProgress(before time.Time, live []int) (int, error) {
+	query := "UPDATE quiz_progress SET status = ? WHERE status = ? AND updated_at < ?"
+	args := []any{models.AttemptAbandoned, models.AttemptInProgress, before}
+	if len(live) > 0 {
+		query += " AND id NOT IN (" + strings.TrimSuffix(strings.Repeat("?,", len(live)), ",") + ")"
+		for _, id := range live {
+			args = append(args, id)
+		}
+	}
+	res, err := d.exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// joinIDs et splitIDs stockent la liste ordonnée des questions d'une partie
+// dans une seule colonne ("12,4,7")
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitIDs(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	ids := make([]int, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"errors"
+	"quizz-ssh/models"
+	"testing"
+	"time"
+)
+
+// startProgress enregistre une nouvelle partie en cours
+func startProgress(t *testing.T, store Store, identity, username string) models.Progress {
+	t.Helper()
+	p := models.Progress{
+		Identity:          identity,
+		Username:          username,
+		Category:          "Cybersecurity",
+		Seed:              1,
+		QuestionIDs:       []int{1, 2},
+		QuestionRevisions: []int{1, 3},
+		Status:            models.AttemptInProgress,
+	}
+	id, err := store.SaveProgress(p)
+	if err != nil {
+		t.Fatalf("SaveProgress: %v", err)
+	}
+	p.ID = id
+	return p
+}
+
+func TestGetOpenProgressByIdentity(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			want := startProgress(t, store, "SHA256:alice", "alice")
+			startProgress(t, store, "SHA256:mallory", "mallory")
+
+			tests := []struct {
+				identity, username string
+				found              bool
+			}{
+				{"SHA256:alice", "alice", true},
+				{"SHA256:mallory", "alice", false}, // Même pseudo, autre clé
+				{"SHA256:alice", "bob", false},     // Même clé, autre pseudo
+				{"", "alice", false},
+			}
+			for _, tt := range tests {
+				p, ok, err := store.GetOpenProgress(tt.identity, tt.username)
+				if err != nil {
+					t.Fatalf("GetOpenProgress: %v", err)
+				}
+				if ok != tt.found {
+					t.Errorf("GetOpenProgress(%q, %q): trouvée = %v, attendu %v", tt.identity, tt.username, ok, tt.found)
+				}
+				if ok && (p.ID != want.ID || len(p.QuestionRevisions) != 2 || p.QuestionRevisions[1] != 3) {
+					t.Errorf("GetOpenProgress(%q, %q) = %+v, attendu la partie #%d et ses versions", tt.identity, tt.username, p, want.ID)
+				}
+			}
+		})
+	}
+}
+
+// Une partie abandonnée (délai de reprise écoulé) ne redevient pas en cours
+// quand la session qui la jouait enregistre une réponse
+func TestSaveProgressAfterAbandon(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			p := startProgress(t, store, "SHA256:alice", "alice")
+			n, err := store.AbandonStaleProgress(time.Now().Add(time.Minute), nil)
+			if err != nil || n != 1 {
+				t.Fatalf("AbandonStaleProgress() = %d, %v, attendu 1", n, err)
+			}
+
+			p.CurrentIndex = 1
+			p.Answers = []models.Answer{{QuestionID: 1, Revision: 1, Correct: true}}
+			if _, err := store.SaveProgress(p); !errors.Is(err, ErrProgressAbandoned) {
+				t.Errorf("SaveProgress: %v, attendu %v", err, ErrProgressAbandoned)
+			}
+			if err := store.SetProgressStatus(p.ID, models.AttemptAbandoned); !errors.Is(err, ErrProgressAbandoned) {
+				t.Errorf("SetProgressStatus: %v, attendu %v", err, ErrProgressAbandoned)
+			}
+			if _, ok, _ := store.GetOpenProgress("SHA256:alice", "alice"); ok {
+				t.Error("partie abandonnée de nouveau en cours")
+			}
+		})
+	}
+}
+
+func TestAbandonStaleProgressSkipsLiveSessions(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			live := startProgress(t, store, "SHA256:alice", "alice")
+			startProgress(t, store, "SHA256:bob", "bob")
+
+			n, err := store.AbandonStaleProgress(time.Now().Add(time.Minute), []int{live.ID})
+			if err != nil || n != 1 {
+				t.Fatalf("AbandonStaleProgress() = %d, %v, attendu 1", n, err)
+			}
+			if _, ok, _ := store.GetOpenProgress("SHA256:alice", "alice"); !ok {
+				t.Error("partie d'une session ouverte abandonnée")
+			}
+			if _, ok, _ := store.GetOpenProgress("SHA256:bob", "bob"); ok {
+				t.Error("partie interrompue non abandonnée")
+			}
+		})
+	}
+}
+
+// Une partie reprise pose les questions dans la version d'origine, même
+// corrigées entre-temps
+func TestGetQuestionRevision(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			q := importTestQuestions(t, store)[0]
+			original := q.Text
+			q.Text = "Énoncé corrigé"
+			q.Options = []string{"Vrai", "Faux", "Je ne sais pas"}
+			if _, err := store.SaveQuestion(q); err != nil {
+				t.Fatalf("SaveQuestion: %v", err)
+			}
+
+			tests := []struct {
+				revision int
+				text     string
+				options  int
+			}{
+				{1, original, 2},
+				{2, "Énoncé corrigé", 3},
+			}
+			for _, tt := range tests {
+				got, err := store.GetQuestionRevision(q.ID, tt.revision)
+				if err != nil {
+					t.Fatalf("GetQuestionRevision(%d, %d): %v", q.ID, tt.revision, err)
+				}
+				if got.Revision != tt.revision || got.Text != tt.text || len(got.Options) != tt.options {
+					t.Errorf("version %d: %+v, attendu %q avec %d réponses", tt.revision, got, tt.text, tt.options)
+				}
+			}
+			if _, err := store.GetQuestionRevision(q.ID, 3); !errors.Is(err, ErrQuestionNotFound) {
+				t.Errorf("version inconnue: %v, attendu %v", err, ErrQuestionNotFound)
+			}
+		})
+	}
+}
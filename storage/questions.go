@@ -1,12 +1,16 @@
 package storage
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"quizz-ssh/models"
+	"time"
 )
 
-// LoadQuestions charge les questions depuis un fichier JSON
+// LoadQuestions charge les questions depuis un fichier JSON (pour les importer dans la banque)
 func LoadQuestions(filepath string) ([]models.Question, error) {
 	data, err := os.ReadFile(filepath)
 	if err != nil {
@@ -50,3 +54,335 @@ func GetUniqueCategories(questions []models.Question) []string {
 	}
 	return categories
 }
+
+// ErrQuestionNotFound est retournée pour une question inconnue ou supprimée
+var ErrQuestionNotFound = errors.New("question introuvable")
+
+// currentRevisions sélectionne la version courante des questions non supprimées
+const currentRevisions = `
+	FROM questions q
+	JOIN question_revisions r ON r.question_id = q.id AND r.revision = q.current_revision
+	JOIN categories c ON c.id = r.category_id
+	WHERE q.deleted_at IS NULL`
+
+// allRevisions sélectionne toutes les versions des questions non supprimées
+const allRevisions = `
+	FROM questions q
+	JOIN question_revisions r ON r.question_id = q.id
+	JOIN categories c ON c.id = r.category_id
+	WHERE q.deleted_at IS NULL`
+
+// CountQuestions compte les questions de la banque
+func (d *Database) CountQuestions() (int, error) {
+	var count int
+	err := d.queryRow("SELECT COUNT(*) FROM questions WHERE deleted_at IS NULL").Scan(&count)
+	return count, err
+}
+
+// GetQuestions récupère la version courante de toutes les questions de la banque,
+// y compris celles désactivées (Disabled) que les quiz doivent écarter
+func (d *Database) GetQuestions() ([]models.Question, error) {
+	return d.loadQuestions(currentRevisions)
+}
+
+// GetQuestion récupère la version courante d'une question
+func (d *Database) GetQuestion(id int) (models.Question, error) {
+	return d.loadQuestion(currentRevisions+" AND q.id = ?", id)
+}
+
+// GetQuestionRevision récupère une version donnée d'une question, telle
+// qu'elle a été posée
+func (d *Database) GetQuestionRevision(id, revision int) (models.Question, error) {
+	return d.loadQuestion(allRevisions+" AND q.id = ? AND r.revision = ?", id, revision)
+}
+
+// loadQuestion charge la seule version de question sélectionnée par revisions
+func (d *Database) loadQuestion(revisions string, args ...any) (models.Question, error) {
+	questions, err := d.loadQuestions(revisions, args...)
+	if err != nil {
+		return models.Question{}, err
+	}
+	if len(questions) == 0 {
+		return models.Question{}, ErrQuestionNotFound
+	}
+	return questions[0], nil
+}
+
+// loadQuestions charge les versions de questions sélectionnées par revisions
+// (currentRevisions ou allRevisions, complétée d'un filtre) avec leurs réponses
+func (d *Database) loadQuestions(revisions string, args ...any) ([]models.Question, error) {
+	rows, err := d.query(`
+		SELECT q.id, r.id, r.revision, c.name, r.text, r.answer, r.explanation, r.difficulty, q.disabled, r.lang
+		`+revisions+`
+		ORDER BY q.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []models.Question
+	byRevision := make(map[int]int) // id de version -> index dans questions
+	for rows.Next() {
+		var q models.Question
+		var revisionID int
+		if err := rows.Scan(&q.ID, &revisionID, &q.Revision, &q.Category, &q.Text, &q.Answer, &q.Explanation, &q.Difficulty, &q.Disabled, &q.Lang); err != nil {
+			return nil, err
+		}
+		byRevision[revisionID] = len(questions)
+		questions = append(questions, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	options, err := d.query(`
+		SELECT o.revision_id, o.text
+		FROM question_options o
+		WHERE o.revision_id IN (SELECT r.id `+revisions+`)
+		ORDER BY o.revision_id, o.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer options.Close()
+
+	for options.Next() {
+		var revisionID int
+		var text string
+		if err := options.Scan(&revisionID, &text); err != nil {
+			return nil, err
+		}
+		if i, ok := byRevision[revisionID]; ok {
+			questions[i].Options = append(questions[i].Options, text)
+		}
+	}
+	if err := options.Err(); err != nil {
+		return nil, err
+	}
+	options.Close()
+
+	return questions, d.loadTranslations(questions, byRevision, revisions, args...)
+}
+
+// loadTranslations complète les questions chargées avec leurs traductions
+func (d *Database) loadTranslations(questions []models.Question, byRevision map[int]int, revisions string, args ...any) error {
+	rows, err := d.query(`
+		SELECT t.revision_id, t.lang, t.text, t.explanation
+		FROM question_translations t
+		WHERE t.revision_id IN (SELECT r.id `+revisions+`)
+		ORDER BY t.revision_id, t.lang`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revisionID int
+		var lang string
+		var t models.Translation
+		if err := rows.Scan(&revisionID, &lang, &t.Text, &t.Explanation); err != nil {
+			return err
+		}
+		i, ok := byRevision[revisionID]
+		if !ok {
+			continue
+		}
+		if questions[i].Translations == nil {
+			questions[i].Translations = make(map[string]models.Translation)
+		}
+		questions[i].Translations[lang] = t
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	options, err := d.query(`
+		SELECT o.revision_id, o.lang, o.text
+		FROM question_option_translations o
+		WHERE o.revision_id IN (SELECT r.id `+revisions+`)
+		ORDER BY o.revision_id, o.lang, o.position`, args...)
+	if err != nil {
+		return err
+	}
+	defer options.Close()
+
+	for options.Next() {
+		var revisionID int
+		var lang, text string
+		if err := options.Scan(&revisionID, &lang, &text); err != nil {
+			return err
+		}
+		i, ok := byRevision[revisionID]
+		if !ok {
+			continue
+		}
+		if t, ok := questions[i].Translations[lang]; ok {
+			t.Options = append(t.Options, text)
+			questions[i].Translations[lang] = t
+		}
+	}
+	return options.Err()
+}
+
+// ImportQuestions ajoute des questions (lues par LoadQuestions) à la banque, en une seule transaction.
+// Les identifiants du fichier sont ignorés: la base attribue les siens.
+func (d *Database) ImportQuestions(questions []models.Question) (int, error) {
+	if err := validateImport(questions); err != nil {
+		return 0, err
+	}
+
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer rollback()
+
+	for _, q := range questions {
+		if _, err := d.createQuestion(tx, q); err != nil {
+			return 0, err
+		}
+	}
+	return len(questions), tx.Commit()
+}
+
+// validateImport vérifie toutes les questions d'un import avant d'en enregistrer une seule
+func validateImport(questions []models.Question) error {
+	for i, q := range questions {
+		if err := q.Validate(); err != nil {
+			return fmt.Errorf("question %d (%q): %w", i+1, models.Truncate(q.Text, 40), err)
+		}
+	}
+	return nil
+}
+
+// SaveQuestion crée la question (ID nul) ou en enregistre une nouvelle version.
+// Les anciennes versions sont conservées: les parties déjà jouées y font référence.
+func (d *Database) SaveQuestion(q models.Question) (models.Question, error) {
+	if err := q.Validate(); err != nil {
+		return q, err
+	}
+
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return q, err
+	}
+	defer rollback()
+
+	if q.ID == 0 {
+		if q, err = d.createQuestion(tx, q); err != nil {
+			return q, err
+		}
+		return q, tx.Commit()
+	}
+
+	var current int
+	err = tx.QueryRow(d.dialect.rebind(
+		"SELECT current_revision FROM questions WHERE id = ? AND deleted_at IS NULL"), q.ID,
+	).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return q, ErrQuestionNotFound
+	}
+	if err != nil {
+		return q, err
+	}
+
+	q.Revision = current + 1
+	if err := d.insertRevision(tx, q); err != nil {
+		return q, err
+	}
+	_, err = tx.Exec(d.dialect.rebind("UPDATE questions SET current_revision = ? WHERE id = ?"), q.Revision, q.ID)
+	if err != nil {
+		return q, err
+	}
+	return q, tx.Commit()
+}
+
+// DeleteQuestion retire une question de la banque (ses versions restent pour l'historique)
+func (d *Database) DeleteQuestion(id int) error {
+	res, err := d.exec("UPDATE questions SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrQuestionNotFound
+	}
+	return err
+}
+
+// SetQuestionDisabled retire une question des quiz ou l'y remet
+func (d *Database) SetQuestionDisabled(id int, disabled bool) error {
+	res, err := d.exec("UPDATE questions SET disabled = ? WHERE id = ? AND deleted_at IS NULL", disabled, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrQuestionNotFound
+	}
+	return err
+}
+
+// createQuestion insère une nouvelle question et sa première version
+func (d *Database) createQuestion(tx *sql.Tx, q models.Question) (models.Question, error) {
+	id, err := d.dialect.insert(tx, "INSERT INTO questions (current_revision, created_at) VALUES (1, ?)", time.Now())
+	if err != nil {
+		return q, err
+	}
+	q.ID = id
+	q.Revision = 1
+	return q, d.insertRevision(tx, q)
+}
+
+// insertRevision enregistre le contenu de q comme version q.Revision de la question q.ID
+func (d *Database) insertRevision(tx *sql.Tx, q models.Question) error {
+	_, err := tx.Exec(d.dialect.rebind(
+		"INSERT INTO categories (name) VALUES (?) ON CONFLICT (name) DO NOTHING"), q.Category)
+	if err != nil {
+		return err
+	}
+	var categoryID int
+	err = tx.QueryRow(d.dialect.rebind("SELECT id FROM categories WHERE name = ?"), q.Category).Scan(&categoryID)
+	if err != nil {
+		return err
+	}
+
+	revisionID, err := d.dialect.insert(tx, `
+		INSERT INTO question_revisions (question_id, revision, category_id, text, answer, explanation, difficulty, lang, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		q.ID, q.Revision, categoryID, q.Text, q.Answer, q.Explanation, q.Difficulty, q.Lang, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, opt := range q.Options {
+		_, err := tx.Exec(d.dialect.rebind(
+			"INSERT INTO question_options (revision_id, position, text) VALUES (?, ?, ?)"),
+			revisionID, i, opt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Les traductions font partie de la version: elles sont recopiées à chaque modification
+	for lang, t := range q.Translations {
+		_, err := tx.Exec(d.dialect.rebind(
+			"INSERT INTO question_translations (revision_id, lang, text, explanation) VALUES (?, ?, ?, ?)"),
+			revisionID, lang, t.Text, t.Explanation,
+		)
+		if err != nil {
+			return err
+		}
+		for i, opt := range t.Options {
+			_, err := tx.Exec(d.dialect.rebind(
+				"INSERT INTO question_option_translations (revision_id, lang, position, text) VALUES (?, ?, ?, ?)"),
+				revisionID, lang, i, opt,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
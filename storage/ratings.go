@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"quizz-ssh/models"
+	"quizz-ssh/rating"
+	"sort"
+	"time"
+)
+
+// GetRating récupère le niveau d'un joueur (niveau initial s'il n'a jamais joué)
+func (d *Database) GetRating(username, category string) (models.Rating, error) {
+	r := models.Rating{Username: username, Category: category, Rating: rating.Initial}
+	err := d.queryRow(
+		"SELECT rating, games, updated_at FROM ratings WHERE username = ? AND category = ?",
+		username, category,
+	).Scan(&r.Rating, &r.Games, &r.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r, nil
+	}
+	return r, err
+}
+
+// UpdateRatings met à jour, dans une seule transaction, les niveaux de joueurs
+// dans une catégorie: update reçoit leurs niveaux actuels (dans l'ordre de
+// usernames) et retourne les nouveaux, ajoutés à leur historique. Les lignes
+// sont verrouillées pendant le calcul: deux parties qui se terminent en même
+// temps pour un même joueur ne s'écrasent pas.
+func (d *Database) UpdateRatings(category, source string, usernames []string, update func(current []models.Rating) []float64) error {
+	tx, rollback, err := d.begin()
+	if err != nil {
+		return err
+	}
+	defer rollback()
+
+	// Créer d'abord les lignes manquantes: cette écriture prend aussi le verrou
+	// d'écriture de SQLite avant toute lecture. Dans l'ordre alphabétique, pour
+	// que deux duels croisés ne se bloquent pas mutuellement sous PostgreSQL.
+	now := time.Now()
+	sorted := append([]string(nil), usernames...)
+	sort.Strings(sorted)
+	for _, username := range sorted {
+		_, err := tx.Exec(d.dialect.rebind(`
+			INSERT INTO ratings (username, category, rating, games, updated_at)
+			VALUES (?, ?, ?, 0, ?)
+			ON CONFLICT(username, category) DO NOTHING
+		`), username, category, rating.Initial, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	current := make([]models.Rating, len(usernames))
+	for i, username := range usernames {
+		current[i] = models.Rating{Username: username, Category: category}
+		err := tx.QueryRow(d.dialect.rebind(
+			"SELECT rating, games, updated_at FROM ratings WHERE username = ? AND category = ?"+d.dialect.forUpdate()),
+			username, category,
+		).Scan(&current[i].Rating, &current[i].Games, &current[i].UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, newRating := range update(current) {
+		_, err := tx.Exec(d.dialect.rebind(
+			"UPDATE ratings SET rating = ?, games = games + 1, updated_at = ? WHERE username = ? AND category = ?"),
+			newRating, now, usernames[i], category,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(d.dialect.rebind(
+			"INSERT INTO rating_history (username, category, rating, delta, source, created_at) VALUES (?, ?, ?, ?, ?, ?)"),
+			usernames[i], category, newRating, newRating-current[i].Rating, source, now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetRatingLeaderboard récupère les meilleurs niveaux pour une catégorie ("global" pour le niveau général)
+func (d *Database) GetRatingLeaderboard(category string, limit int) ([]models.Rating, error) {
+	if category == "" {
+		category = "global"
+	}
+
+	rows, err := d.query(`
+		SELECT username, category, rating, games, updated_at
+		FROM ratings
+		WHERE category = ?
+		ORDER BY rating DESC, games DESC, username
+		LIMIT ?
+	`, category, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []models.Rating
+	for rows.Next() {
+		var r models.Rating
+		if err := rows.Scan(&r.Username, &r.Category, &r.Rating, &r.Games, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, r)
+	}
+	return ratings, rows.Err()
+}
+
+// GetRatingHistory récupère les dernières évolutions de niveau d'un joueur, de la plus récente à la plus ancienne
+func (d *Database) GetRatingHistory(username, category string, limit int) ([]models.RatingChange, error) {
+	rows, err := d.query(`
+		SELECT username, category, rating, delta, source, created_at
+		FROM rating_history
+		WHERE username = ? AND category = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, username, category, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.RatingChange
+	for rows.Next() {
+		var c models.RatingChange
+		if err := rows.Scan(&c.Username, &c.Category, &c.Rating, &c.Delta, &c.Source, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
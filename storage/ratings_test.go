@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"quizz-ssh/models"
+	"sync"
+	"testing"
+)
+
+func TestUpdateRatingsConcurrent(t *testing.T) {
+	const finishes = 20
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			// Chaque fin de partie ajoute un point: aucune ne doit être perdue
+			var wg sync.WaitGroup
+			errs := make(chan error, finishes)
+			for range finishes {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- store.UpdateRatings("global", "quiz", []string{"alice"}, func(current []models.Rating) []float64 {
+						return []float64{current[0].Rating + 1}
+					})
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatalf("UpdateRatings: %v", err)
+				}
+			}
+
+			r, err := store.GetRating("alice", "global")
+			if err != nil {
+				t.Fatalf("GetRating: %v", err)
+			}
+			if r.Rating != 1000+finishes || r.Games != finishes {
+				t.Errorf("niveau %v en %d parties, attendu %d en %d", r.Rating, r.Games, 1000+finishes, finishes)
+			}
+			history, err := store.GetRatingHistory("alice", "global", 2*finishes)
+			if err != nil {
+				t.Fatalf("GetRatingHistory: %v", err)
+			}
+			if len(history) != finishes {
+				t.Errorf("%d changements dans l'historique, attendu %d", len(history), finishes)
+			}
+			for _, c := range history {
+				if c.Delta != 1 {
+					t.Errorf("delta %v dans l'historique, attendu 1", c.Delta)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateRatingsDuel(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			err := store.UpdateRatings("global", "duel", []string{"zoe", "alice"}, func(current []models.Rating) []float64 {
+				if current[0].Username != "zoe" || current[1].Username != "alice" {
+					t.Errorf("niveaux dans le désordre: %s, %s", current[0].Username, current[1].Username)
+				}
+				return []float64{1010, 990}
+			})
+			if err != nil {
+				t.Fatalf("UpdateRatings: %v", err)
+			}
+			for username, want := range map[string]float64{"zoe": 1010, "alice": 990} {
+				r, err := store.GetRating(username, "global")
+				if err != nil {
+					t.Fatalf("GetRating: %v", err)
+				}
+				if r.Rating != want || r.Games != 1 {
+					t.Errorf("%s: niveau %v en %d partie(s), attendu %v en 1", username, r.Rating, r.Games, want)
+				}
+			}
+		})
+	}
+}
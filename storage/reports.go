@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"quizz-ssh/models"
+	"time"
+)
+
+// SaveReport enregistre le signalement d'une question par un joueur
+func (d *Database) SaveReport(r models.Report) error {
+	_, err := d.exec(
+		"INSERT INTO question_reports (question_id, revision, username, reason, created_at) VALUES (?, ?, ?, ?, ?)",
+		r.QuestionID, r.Revision, r.Username, r.Reason, time.Now(),
+	)
+	return err
+}
+
+// GetOpenReports récupère les signalements en attente, du plus ancien au plus récent
+func (d *Database) GetOpenReports() ([]models.Report, error) {
+	rows, err := d.query(`
+		SELECT id, question_id, revision, username, reason, created_at
+		FROM question_reports
+		WHERE resolution = ''
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []models.Report
+	for rows.Next() {
+		var r models.Report
+		if err := rows.Scan(&r.ID, &r.QuestionID, &r.Revision, &r.Username, &r.Reason, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, rows.Err()
+}
+
+// CountOpenReports compte les signalements en attente de chaque question
+func (d *Database) CountOpenReports() (map[int]int, error) {
+	rows, err := d.query("SELECT question_id, COUNT(*) FROM question_reports WHERE resolution = '' GROUP BY question_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var id, count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+	return counts, rows.Err()
+}
+
+// ResolveReports clôt tous les signalements en attente d'une question et retourne leur nombre
+func (d *Database) ResolveReports(questionID int, resolution string) (int, error) {
+	res, err := d.exec(
+		"UPDATE question_reports SET resolution = ?, resolved_at = ? WHERE question_id = ? AND resolution = ''",
+		resolution, time.Now(), questionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
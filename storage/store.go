@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"quizz-ssh/models"
+	"strings"
+	"time"
+)
+
+// Store regroupe toutes les opérations de persistance du serveur.
+// Database l'implémente sur SQLite ou PostgreSQL, MemoryStore en mémoire.
+type Store interface {
+	// Parties et classements
+	SaveAttempt(score models.Score, answers []models.Answer) (int, error)
+	DeleteScore(id int) error
+	GetRecordedAnswers() ([]models.RecordedAnswer, error)
+	GetLeaderboard(category string, period models.Period, limit int) ([]models.Score, error)
+	GetLeaderboardPage(category string, period models.Period, search string, offset, limit int) ([]models.Score, int, error)
+	GetUserRank(category string, period models.Period, username string) (score models.Score, ok bool, err error)
+	GetCategories() ([]string, error)
+	GetStats() (games, players int, err error)
+
+	// Parties en cours, reprenables après une déconnexion
+	SaveProgress(p models.Progress) (int, error)
+	GetOpenProgress(identity, username string) (p models.Progress, ok bool, err error)
+	SetProgressStatus(id int, status models.AttemptStatus) error
+	AbandonStaleProgress(before time.Time, live []int) (int, error)
+
+	// Joueurs
+	GetUserBestScore(username, category string) (int, error)
+	GetUserStats(username string) (models.UserStats, error)
+	GetUserHistory(username string, limit int) ([]models.Score, error)
+	GetUserBestScores(username string) ([]models.Score, error)
+	GetUserCategoryRates(username string) (map[string]float64, error)
+	GetPlayStreak(username string) (int, error)
+	GetPreferences(username string) (models.Preferences, error)
+	SavePreferences(p models.Preferences) error
+
+	// Duels et niveaux
+	SaveDuel(duel models.Duel) error
+	GetDuelRecord(username string) (models.DuelRecord, error)
+	GetRating(username, category string) (models.Rating, error)
+	UpdateRatings(category, source string, usernames []string, update func(current []models.Rating) []float64) error
+	GetRatingLeaderboard(category string, limit int) ([]models.Rating, error)
+	GetRatingHistory(username, category string, limit int) ([]models.RatingChange, error)
+
+	// Banque de questions
+	CountQuestions() (int, error)
+	GetQuestions() ([]models.Question, error)
+	GetQuestion(id int) (models.Question, error)
+	GetQuestionRevision(id, revision int) (models.Question, error)
+	ImportQuestions(questions []models.Question) (int, error)
+	SaveQuestion(q models.Question) (models.Question, error)
+	DeleteQuestion(id int) error
+	SetQuestionDisabled(id int, disabled bool) error
+
+	// Signalements de questions
+	SaveReport(r models.Report) error
+	GetOpenReports() ([]models.Report, error)
+	CountOpenReports() (map[int]int, error)
+	ResolveReports(questionID int, resolution string) (int, error)
+
+	// Badges
+	GetUserAchievements(username string) ([]models.UserAchievement, error)
+	GetAchievementCodes(usernames []string) (map[string][]string, error)
+	UnlockAchievement(username, code string) error
+
+	// Schéma
+	Migrate(seed Seed) (applied, imported int, err error)
+	MigrationStatus() ([]MigrationStatus, error)
+	Close() error
+}
+
+// Seed fournit les questions importées au premier démarrage, quand la banque
+// n'a encore jamais eu de question; elle n'est appelée que dans ce cas
+type Seed func() []models.Question
+
+// MemoryDSN sélectionne le stockage en mémoire (tests, démonstrations)
+const MemoryDSN = "memory"
+
+// Open ouvre le stockage désigné par dsn sans appliquer de migration:
+// "postgres://..." pour PostgreSQL, "memory" pour la mémoire, sinon un chemin de fichier SQLite
+func Open(dsn string) (Store, error) {
+	switch {
+	case dsn == MemoryDSN:
+		return NewMemoryStore(), nil
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return OpenPostgres(dsn)
+	default:
+		return OpenDatabase(dsn)
+	}
+}
+
+// NewStore ouvre le stockage désigné par dsn, applique les migrations en
+// attente et importe les questions de seed (si non nil) dans une banque qui
+// n'a jamais eu de question
+func NewStore(dsn string, seed Seed) (Store, error) {
+	s, err := Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, imported, err := s.Migrate(seed)
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("migrations: %w", err)
+	}
+	if applied > 0 {
+		log.Printf("🗄️  %d migration(s) appliquée(s)", applied)
+	}
+	if imported > 0 {
+		log.Printf("📥 %d questions importées dans la banque", imported)
+	}
+
+	return s, nil
+}
+
+var (
+	_ Store = (*Database)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
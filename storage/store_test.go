@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"quizz-ssh/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postgresTestDSN désigne une base PostgreSQL jetable pour exécuter aussi les
+// tests sur ce moteur; son schéma public est vidé par chaque test
+var postgresTestDSN = os.Getenv("QUIZ_TEST_POSTGRES_DSN")
+
+// openRawTestDatabase ouvre une base vide, sans migration: SQLite dans un
+// répertoire temporaire ou, pour "postgres", la base de QUIZ_TEST_POSTGRES_DSN
+func openRawTestDatabase(t *testing.T, engine string) *Database {
+	t.Helper()
+	var d *Database
+	var err error
+	if engine == "postgres" {
+		if postgresTestDSN == "" {
+			t.Skip("QUIZ_TEST_POSTGRES_DSN non défini")
+		}
+		if d, err = OpenPostgres(postgresTestDSN); err == nil {
+			_, err = d.db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public")
+		}
+	} else {
+		d, err = OpenDatabase(filepath.Join(t.TempDir(), "quiz.db"))
+	}
+	if err != nil {
+		t.Fatalf("ouverture base %s: %v", engine, err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+// openTestDatabase ouvre une base SQLite migrée dans un répertoire temporaire
+func openTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	d := openRawTestDatabase(t, "sqlite")
+	if _, _, err := d.Migrate(nil); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return d
+}
+
+// testEngines liste les moteurs SQL testés
+func testEngines() []string {
+	if postgresTestDSN != "" {
+		return []string{"sqlite", "postgres"}
+	}
+	return []string{"sqlite"}
+}
+
+// testStores retourne une instance migrée de chaque implémentation de Store
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+	stores := map[string]Store{"memory": NewMemoryStore()}
+	for _, engine := range testEngines() {
+		d := openRawTestDatabase(t, engine)
+		if _, _, err := d.Migrate(nil); err != nil {
+			t.Fatalf("Migrate %s: %v", engine, err)
+		}
+		stores[engine] = d
+	}
+	return stores
+}
+
+// populate joue le même scénario sur un stockage: banque modifiée, parties,
+// duels, niveaux, signalements, badges, préférences et partie en cours
+func populate(t *testing.T, s Store) {
+	t.Helper()
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	questions := importTestQuestions(t, s)
+	edited := questions[0]
+	edited.Text += " (corrigée)"
+	_, err := s.SaveQuestion(edited)
+	check(err)
+	check(s.SetQuestionDisabled(questions[1].ID, true))
+	check(s.DeleteQuestion(questions[3].ID))
+	questions, err = s.GetQuestions()
+	check(err)
+
+	playAttempt(t, s, "alice", questions, map[string]int{"Cryptographie": 2, "Réseau": 1})
+	playAttempt(t, s, "bob", questions, map[string]int{"Cryptographie": 1})
+	playAttempt(t, s, "carol", questions, map[string]int{"Cryptographie": 2, "Réseau": 1})
+	playAttempt(t, s, "alice", questions, map[string]int{"Réseau": 1})
+	_, err = s.SaveAttempt(models.Score{Username: "bob", Category: "Réseau", Score: 1, Total: 1, Duration: time.Second}, nil)
+	check(err)
+
+	check(s.SaveDuel(models.Duel{Player1: "alice", Player2: "bob", Score1: 3, Score2: 1, Total: 5, Winner: "alice"}))
+	check(s.SaveDuel(models.Duel{Player1: "bob", Player2: "alice", Score1: 2, Score2: 2, Total: 5}))
+	check(s.UpdateRatings("global", "duel", []string{"alice", "bob"}, func(current []models.Rating) []float64 {
+		return []float64{current[0].Rating + 16, current[1].Rating - 16}
+	}))
+	check(s.UpdateRatings("Cryptographie", "quiz", []string{"carol"}, func(current []models.Rating) []float64 {
+		return []float64{current[0].Rating + 8}
+	}))
+
+	check(s.SaveReport(models.Report{QuestionID: questions[0].ID, Revision: questions[0].Revision, Username: "bob", Reason: "ambiguë"}))
+	check(s.SaveReport(models.Report{QuestionID: questions[0].ID, Revision: questions[0].Revision, Username: "carol", Reason: "faute"}))
+	check(s.SaveReport(models.Report{QuestionID: questions[1].ID, Revision: questions[1].Revision, Username: "alice", Reason: "fausse"}))
+	_, err = s.ResolveReports(questions[1].ID, "corrigée")
+	check(err)
+
+	check(s.UnlockAchievement("alice", "first_perfect"))
+	check(s.UnlockAchievement("alice", "streak_10"))
+	check(s.SavePreferences(models.Preferences{Username: "alice", Lang: "en", Theme: "light", ConfirmAnswers: true}))
+
+	_, err = s.SaveProgress(models.Progress{
+		Identity:          "SHA256:dave",
+		Username:          "dave",
+		Category:          "Cybersecurity",
+		Seed:              42,
+		QuestionIDs:       []int{questions[0].ID, questions[1].ID},
+		QuestionRevisions: []int{questions[0].Revision, questions[1].Revision},
+		CurrentIndex:      1,
+		Answers:           []models.Answer{{QuestionID: questions[0].ID, Revision: questions[0].Revision, Chosen: 1}},
+		Status:            models.AttemptInProgress,
+	})
+	check(err)
+}
+
+// snapshot lit l'état d'un stockage par toutes ses méthodes de lecture
+func snapshot(t *testing.T, s Store) map[string]any {
+	t.Helper()
+	views := make(map[string]any)
+	add := func(name string, v any, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		views[name] = normalize(t, v)
+	}
+
+	v1, err := s.GetLeaderboard("", models.PeriodAll, 10)
+	add("GetLeaderboard", v1, err)
+	v1, err = s.GetLeaderboard("Réseau", models.PeriodWeek, 10)
+	add("GetLeaderboard(Réseau)", v1, err)
+	v1, n, err := s.GetLeaderboardPage("", models.PeriodAll, "O", 1, 2)
+	add("GetLeaderboardPage", []any{v1, n}, err)
+	rank, ok, err := s.GetUserRank("", models.PeriodAll, "bob")
+	add("GetUserRank", []any{rank, ok}, err)
+	categories, err := s.GetCategories()
+	add("GetCategories", categories, err)
+	games, players, err := s.GetStats()
+	add("GetStats", []int{games, players}, err)
+
+	for _, username := range []string{"alice", "bob", "zoe"} {
+		best, err := s.GetUserBestScore(username, "Cybersecurity")
+		add("GetUserBestScore/"+username, best, err)
+		stats, err := s.GetUserStats(username)
+		add("GetUserStats/"+username, stats, err)
+		history, err := s.GetUserHistory(username, 3)
+		add("GetUserHistory/"+username, history, err)
+		bests, err := s.GetUserBestScores(username)
+		add("GetUserBestScores/"+username, bests, err)
+		rates, err := s.GetUserCategoryRates(username)
+		add("GetUserCategoryRates/"+username, rates, err)
+		streak, err := s.GetPlayStreak(username)
+		add("GetPlayStreak/"+username, streak, err)
+		prefs, err := s.GetPreferences(username)
+		add("GetPreferences/"+username, prefs, err)
+		record, err := s.GetDuelRecord(username)
+		add("GetDuelRecord/"+username, record, err)
+		r, err := s.GetRating(username, "global")
+		add("GetRating/"+username, r, err)
+		changes, err := s.GetRatingHistory(username, "global", 5)
+		add("GetRatingHistory/"+username, changes, err)
+		unlocked, err := s.GetUserAchievements(username)
+		add("GetUserAchievements/"+username, unlocked, err)
+	}
+	codes, err := s.GetAchievementCodes([]string{"alice", "bob"})
+	add("GetAchievementCodes", codes, err)
+	ratings, err := s.GetRatingLeaderboard("global", 10)
+	add("GetRatingLeaderboard", ratings, err)
+	ratings, err = s.GetRatingLeaderboard("Cryptographie", 10)
+	add("GetRatingLeaderboard(Cryptographie)", ratings, err)
+
+	count, err := s.CountQuestions()
+	add("CountQuestions", count, err)
+	questions, err := s.GetQuestions()
+	add("GetQuestions", questions, err)
+	if len(questions) > 0 {
+		q, err := s.GetQuestion(questions[0].ID)
+		add("GetQuestion", q, err)
+		q, err = s.GetQuestionRevision(questions[0].ID, 1)
+		add("GetQuestionRevision", q, err)
+	}
+	reports, err := s.GetOpenReports()
+	add("GetOpenReports", reports, err)
+	// Les identifiants diffèrent d'un stockage à l'autre: compter par énoncé
+	open, err := s.CountOpenReports()
+	byText := make(map[string]int)
+	for id, n := range open {
+		q, err := s.GetQuestion(id)
+		if err != nil {
+			t.Fatalf("GetQuestion(%d): %v", id, err)
+		}
+		byText[q.Text] = n
+	}
+	add("CountOpenReports", byText, err)
+	answers, err := s.GetRecordedAnswers()
+	add("GetRecordedAnswers", answers, err)
+
+	p, ok, err := s.GetOpenProgress("SHA256:dave", "dave")
+	add("GetOpenProgress", []any{p, ok}, err)
+	return views
+}
+
+// normalize garde d'une valeur ce qui doit être identique d'un stockage à
+// l'autre: ni identifiants, ni dates, et une liste vide vaut une liste absente
+func normalize(t *testing.T, v any) any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	return strip(out)
+}
+
+func strip(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "id" || strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids") ||
+				strings.HasSuffix(key, "_at") || strings.HasSuffix(key, "_played") {
+				delete(v, key)
+				continue
+			}
+			if value = strip(value); value == nil {
+				delete(v, key)
+			} else {
+				v[key] = value
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i := range v {
+			v[i] = strip(v[i])
+		}
+	}
+	return v
+}
+
+// Les implémentations SQL et en mémoire de Store doivent donner les mêmes
+// résultats pour les mêmes opérations
+func TestStoreParity(t *testing.T) {
+	memory := NewMemoryStore()
+	populate(t, memory)
+	want := snapshot(t, memory)
+
+	for _, engine := range testEngines() {
+		t.Run(engine, func(t *testing.T) {
+			d := openRawTestDatabase(t, engine)
+			if _, _, err := d.Migrate(nil); err != nil {
+				t.Fatalf("Migrate: %v", err)
+			}
+			populate(t, d)
+			got := snapshot(t, d)
+
+			for name, w := range want {
+				if !reflect.DeepEqual(got[name], w) {
+					gotJSON, _ := json.Marshal(got[name])
+					wantJSON, _ := json.Marshal(w)
+					t.Errorf("%s:\n  %s: %s\n  memory: %s", name, engine, gotJSON, wantJSON)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,625 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"quizz-ssh/analytics"
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QuestionBank donne accès en écriture à la banque de questions et aux signalements
+type QuestionBank interface {
+	GetQuestions() ([]models.Question, error)
+	SaveQuestion(q models.Question) (models.Question, error)
+	DeleteQuestion(id int) error
+	SetQuestionDisabled(id int, disabled bool) error
+
+	GetOpenReports() ([]models.Report, error)
+	CountOpenReports() (map[int]int, error)
+	ResolveReports(questionID int, resolution string) (int, error)
+
+	GetRecordedAnswers() ([]models.RecordedAnswer, error)
+}
+
+// Nombre maximum de réponses proposées par question dans le formulaire
+const adminMaxOptions = 6
+
+type adminScreen int
+
+const (
+	adminCategories adminScreen = iota
+	adminQuestions
+	adminForm
+	adminPreview
+	adminConfirmDelete
+	adminReports
+	adminAnalytics
+	adminAnnounce
+)
+
+// Champs du formulaire, dans l'ordre de navigation
+const (
+	fieldCategory    = 0
+	fieldText        = 1
+	fieldOption      = 2 // fieldOption+i pour la réponse i
+	fieldAnswer      = fieldOption + adminMaxOptions
+	fieldExplanation = fieldAnswer + 1
+	fieldDifficulty  = fieldAnswer + 2
+	fieldCount       = fieldAnswer + 3
+)
+
+// AdminModel est l'espace d'administration de la banque de questions
+type AdminModel struct {
+	lang       i18n.Lang
+	styles     *Styles
+	bank       QuestionBank
+	screen     adminScreen
+	questions  []models.Question
+	categories []string
+	category   string // catégorie parcourue
+	cursor     int
+
+	reports      []models.Report // signalements en attente
+	reportCounts map[int]int     // signalements en attente par question
+
+	stats       []analytics.QuestionStats // analyse des réponses, calculée à l'ouverture de l'écran
+	flaggedOnly bool
+
+	// Formulaire d'édition (editing vaut 0 pour une nouvelle question)
+	editing  int
+	original models.Question // question éditée, dont le formulaire conserve les traductions
+	fields   []textinput.Model
+	focus    int
+	formFrom adminScreen
+
+	preview       models.Question
+	previewResult bool
+	previewFrom   adminScreen
+
+	announce textinput.Model // annonce à diffuser aux joueurs connectés
+
+	status string
+	err    error
+	done   bool
+}
+
+func NewAdminModel(lang i18n.Lang, styles *Styles, bank QuestionBank) AdminModel {
+	m := AdminModel{lang: lang, styles: styles, bank: bank}
+	return m.reload()
+}
+
+// reload recharge la banque, la liste des catégories et les signalements
+func (m AdminModel) reload() AdminModel {
+	m.questions, m.err = m.bank.GetQuestions()
+	if m.err == nil {
+		m.reports, m.err = m.bank.GetOpenReports()
+	}
+	if m.err == nil {
+		m.reportCounts, m.err = m.bank.CountOpenReports()
+	}
+
+	seen := make(map[string]bool)
+	m.categories = nil
+	for _, q := range m.questions {
+		if !seen[q.Category] {
+			seen[q.Category] = true
+			m.categories = append(m.categories, q.Category)
+		}
+	}
+	sort.Strings(m.categories)
+	return m
+}
+
+// categoryQuestions retourne les questions de la catégorie parcourue
+func (m AdminModel) categoryQuestions() []models.Question {
+	var questions []models.Question
+	for _, q := range m.questions {
+		if q.Category == m.category {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+func (m AdminModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m AdminModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.styles = m.styles.Resize(size.Width, size.Height)
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		switch m.screen {
+		case adminForm:
+			return m.updateForm(msg)
+		case adminAnnounce:
+			return m.updateAnnounce(msg)
+		}
+		return m, nil
+	}
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.screen {
+	case adminCategories:
+		return m.updateCategories(key)
+	case adminQuestions:
+		return m.updateQuestions(key)
+	case adminForm:
+		return m.updateForm(msg)
+	case adminPreview:
+		return m.updatePreview(key)
+	case adminConfirmDelete:
+		return m.updateConfirmDelete(key)
+	case adminReports:
+		return m.updateReports(key)
+	case adminAnalytics:
+		return m.updateAnalytics(key)
+	case adminAnnounce:
+		return m.updateAnnounce(msg)
+	}
+	return m, nil
+}
+
+func (m AdminModel) updateCategories(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "esc", "q":
+		m.done = true
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.categories)-1 {
+			m.cursor++
+		}
+	case "enter", " ":
+		if len(m.categories) > 0 {
+			m.category = m.categories[m.cursor]
+			m.screen = adminQuestions
+			m.cursor = 0
+			m.status = ""
+		}
+	case "n":
+		return m.openForm(models.Question{}, adminCategories)
+	case "r":
+		m.screen = adminReports
+		m.cursor = 0
+		m.status = ""
+	case "a":
+		return m.openAnalytics(), nil
+	case "m":
+		return m.openAnnounce()
+	}
+	return m, nil
+}
+
+func (m AdminModel) updateQuestions(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	questions := m.categoryQuestions()
+	switch key.String() {
+	case "esc", "q":
+		m.screen = adminCategories
+		m.cursor = max(0, indexOf(m.categories, m.category))
+		m.status = ""
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(questions)-1 {
+			m.cursor++
+		}
+	case "n":
+		return m.openForm(models.Question{Category: m.category}, adminQuestions)
+	}
+
+	if len(questions) == 0 {
+		return m, nil
+	}
+	selected := questions[min(m.cursor, len(questions)-1)]
+	switch key.String() {
+	case "enter", "e":
+		return m.openForm(selected, adminQuestions)
+	case "p":
+		m.preview = selected
+		m.previewResult = false
+		m.previewFrom = adminQuestions
+		m.screen = adminPreview
+	case "d":
+		m.preview = selected
+		m.screen = adminConfirmDelete
+	case "x":
+		m = m.setDisabled(selected, !selected.Disabled)
+	}
+	return m, nil
+}
+
+// setDisabled retire une question des quiz ou l'y remet
+func (m AdminModel) setDisabled(q models.Question, disabled bool) AdminModel {
+	if err := m.bank.SetQuestionDisabled(q.ID, disabled); err != nil {
+		m.status = m.lang.T("admin.update_failed", err)
+		return m
+	}
+	if disabled {
+		m.status = m.lang.T("admin.disabled", q.ID)
+	} else {
+		m.status = m.lang.T("admin.enabled", q.ID)
+	}
+	return m.reload()
+}
+
+func (m AdminModel) updatePreview(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "tab", " ":
+		m.previewResult = !m.previewResult
+	case "esc", "q", "enter":
+		m.screen = m.previewFrom
+		if m.screen == adminForm {
+			return m, m.fields[m.focus].Focus()
+		}
+	}
+	return m, nil
+}
+
+func (m AdminModel) updateConfirmDelete(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "y", "o":
+		if err := m.bank.DeleteQuestion(m.preview.ID); err != nil {
+			m.status = m.lang.T("admin.delete_failed", err)
+		} else {
+			m.status = m.lang.T("admin.deleted", m.preview.ID)
+		}
+		m = m.reload()
+		m.cursor = max(0, min(m.cursor, len(m.categoryQuestions())-1))
+		m.screen = adminQuestions
+	case "n", "esc", "q":
+		m.screen = adminQuestions
+	}
+	return m, nil
+}
+
+// openForm ouvre le formulaire pré-rempli avec la question (ID nul pour une création)
+func (m AdminModel) openForm(q models.Question, from adminScreen) (tea.Model, tea.Cmd) {
+	m.fields = make([]textinput.Model, fieldCount)
+	for i := range m.fields {
+		ti := textinput.New()
+		ti.Width = 60
+		ti.CharLimit = 200
+		ti.Prompt = ""
+		m.fields[i] = ti
+	}
+	m.fields[fieldText].CharLimit = 500
+	m.fields[fieldExplanation].CharLimit = 500
+	m.fields[fieldAnswer].CharLimit = 1
+	m.fields[fieldDifficulty].CharLimit = 1
+	m.fields[fieldAnswer].Placeholder = fmt.Sprintf("1-%d", adminMaxOptions)
+	m.fields[fieldDifficulty].Placeholder = m.lang.T("admin.difficulty_hint")
+
+	m.fields[fieldCategory].SetValue(q.Category)
+	m.fields[fieldText].SetValue(q.Text)
+	for i, opt := range q.Options {
+		if i < adminMaxOptions {
+			m.fields[fieldOption+i].SetValue(opt)
+		}
+	}
+	if q.ID != 0 {
+		m.fields[fieldAnswer].SetValue(strconv.Itoa(q.Answer + 1))
+	}
+	m.fields[fieldExplanation].SetValue(q.Explanation)
+	if q.Difficulty > 0 {
+		m.fields[fieldDifficulty].SetValue(strconv.Itoa(q.Difficulty))
+	}
+
+	m.editing = q.ID
+	m.original = q
+	m.formFrom = from
+	m.screen = adminForm
+	m.err = nil
+	m.status = ""
+	m.focus = fieldCategory
+	if q.Category != "" {
+		m.focus = fieldText
+	}
+	return m, m.fields[m.focus].Focus()
+}
+
+func (m AdminModel) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			m.screen = m.formFrom
+			m.err = nil
+			return m, nil
+		case "tab", "down", "enter":
+			return m.focusField(m.focus + 1)
+		case "shift+tab", "up":
+			return m.focusField(m.focus - 1)
+		case "ctrl+p":
+			q, err := m.formQuestion()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.fields[m.focus].Blur()
+			m.preview = q
+			m.previewResult = false
+			m.previewFrom = adminForm
+			m.screen = adminPreview
+			return m, nil
+		case "ctrl+s":
+			return m.save()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.fields[m.focus], cmd = m.fields[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m AdminModel) focusField(i int) (tea.Model, tea.Cmd) {
+	m.fields[m.focus].Blur()
+	m.focus = (i + fieldCount) % fieldCount
+	return m, m.fields[m.focus].Focus()
+}
+
+// formQuestion construit la question saisie et la valide
+func (m AdminModel) formQuestion() (models.Question, error) {
+	q := models.Question{
+		ID:           m.editing,
+		Category:     strings.TrimSpace(m.fields[fieldCategory].Value()),
+		Text:         strings.TrimSpace(m.fields[fieldText].Value()),
+		Explanation:  strings.TrimSpace(m.fields[fieldExplanation].Value()),
+		Lang:         m.original.Lang,
+		Translations: m.original.Translations,
+	}
+
+	// Les réponses vides en fin de liste sont ignorées
+	last := -1
+	for i := 0; i < adminMaxOptions; i++ {
+		if strings.TrimSpace(m.fields[fieldOption+i].Value()) != "" {
+			last = i
+		}
+	}
+	for i := 0; i <= last; i++ {
+		q.Options = append(q.Options, strings.TrimSpace(m.fields[fieldOption+i].Value()))
+	}
+
+	answer, err := strconv.Atoi(strings.TrimSpace(m.fields[fieldAnswer].Value()))
+	if err != nil {
+		return q, errors.New(m.lang.T("admin.answer_required"))
+	}
+	q.Answer = answer - 1
+
+	if value := strings.TrimSpace(m.fields[fieldDifficulty].Value()); value != "" {
+		if q.Difficulty, err = strconv.Atoi(value); err != nil {
+			return q, errors.New(m.lang.T("admin.difficulty_invalid"))
+		}
+	}
+
+	return q, q.Validate()
+}
+
+func (m AdminModel) save() (tea.Model, tea.Cmd) {
+	q, err := m.formQuestion()
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	saved, err := m.bank.SaveQuestion(q)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	m.status = m.lang.T("admin.saved", saved.ID, saved.Revision)
+
+	// Corriger une question depuis la file de modération clôt ses signalements
+	if m.formFrom == adminReports {
+		if _, err := m.bank.ResolveReports(saved.ID, resolutionFixed); err != nil {
+			m.status = m.lang.T("admin.resolve_failed", err)
+		}
+		m = m.reload()
+		m.screen = adminReports
+		m.cursor = max(0, min(m.cursor, len(m.flaggedQuestions())-1))
+		return m, nil
+	}
+
+	// Depuis l'analyse, on y revient: la nouvelle version repart sans réponses
+	if m.formFrom == adminAnalytics {
+		m = m.reload()
+		m.err = nil
+		status, cursor := m.status, m.cursor
+		m = m.openAnalytics()
+		m.status = status
+		m.cursor = max(0, min(cursor, len(m.visibleStats())-1))
+		return m, nil
+	}
+
+	m = m.reload()
+	m.err = nil
+	m.category = saved.Category
+	m.screen = adminQuestions
+	m.cursor = 0
+	for i, cq := range m.categoryQuestions() {
+		if cq.ID == saved.ID {
+			m.cursor = i
+		}
+	}
+	return m, nil
+}
+
+func (m AdminModel) IsDone() bool {
+	return m.done
+}
+
+func (m AdminModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	switch m.screen {
+	case adminCategories:
+		m.renderCategories(&b)
+	case adminQuestions:
+		m.renderQuestions(&b)
+	case adminForm:
+		m.renderForm(&b)
+	case adminPreview:
+		b.WriteString(m.styles.Title.Render(m.lang.T("admin.preview")) + "\n")
+		b.WriteString(renderQuestionPreview(m.lang, m.styles, m.preview, m.previewResult))
+		b.WriteString(m.styles.Help.Render(m.lang.T("admin.preview_help")) + "\n")
+	case adminReports:
+		m.renderReports(&b)
+	case adminAnalytics:
+		m.renderAnalytics(&b)
+	case adminAnnounce:
+		m.renderAnnounce(&b)
+	case adminConfirmDelete:
+		b.WriteString(m.styles.Title.Render(m.lang.T("admin.delete_title")) + "\n")
+		b.WriteString(m.styles.Box.Render(m.styles.Question.Render(fmt.Sprintf("#%d %s", m.preview.ID, m.preview.Text))) + "\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("admin.delete_note")) + "\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("admin.delete_help")) + "\n")
+	}
+
+	return m.styles.Page.Render(b.String())
+}
+
+func (m AdminModel) renderCategories(b *strings.Builder) {
+	b.WriteString(m.styles.Title.Render(m.lang.T("admin.title")) + "\n")
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("admin.count", len(m.questions))) + "\n\n")
+	m.renderStatus(b)
+	if len(m.reports) > 0 {
+		pending := m.lang.T("admin.pending_reports", len(m.reports))
+		b.WriteString(m.styles.LeaderboardTop.Render(pending) + "\n\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render(m.lang.T("admin.load_error")) + "\n\n")
+	}
+
+	counts := make(map[string]int)
+	for _, q := range m.questions {
+		counts[q.Category]++
+	}
+	for i, cat := range m.categories {
+		line := fmt.Sprintf("%s (%d)", cat, counts[cat])
+		if i == m.cursor {
+			b.WriteString(m.styles.MenuItemSelected.Render("▶ "+line) + "\n")
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  "+line) + "\n")
+		}
+	}
+
+	help := m.styles.Help.Render(m.lang.T("admin.categories_help"))
+	b.WriteString("\n" + help + "\n")
+}
+
+func (m AdminModel) renderQuestions(b *strings.Builder) {
+	questions := m.categoryQuestions()
+	b.WriteString(m.styles.Title.Render(fmt.Sprintf("📂 %s", m.category)) + "\n")
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("admin.category_count", len(questions))) + "\n\n")
+	m.renderStatus(b)
+
+	headerRow := fmt.Sprintf("%-6s %-5s %-5s %-4s %s", "#", m.lang.T("admin.col_revision"),
+		m.lang.T("admin.col_difficulty"), "🚩", m.lang.T("admin.col_text"))
+	b.WriteString(m.styles.LeaderboardHeader.Render(headerRow) + "\n\n")
+
+	// Fenêtre de 12 lignes autour du curseur
+	start := max(0, min(m.cursor-6, len(questions)-12))
+	for i := start; i < len(questions) && i < start+12; i++ {
+		q := questions[i]
+		difficulty := "-"
+		if q.Difficulty > 0 {
+			difficulty = strconv.Itoa(q.Difficulty)
+		}
+		reports := "-"
+		if n := m.reportCounts[q.ID]; n > 0 {
+			reports = strconv.Itoa(n)
+		}
+		text := models.Truncate(q.Text, 50)
+		if q.Disabled {
+			text = "⛔ " + models.Truncate(q.Text, 47)
+		}
+		row := fmt.Sprintf("%-6s %-5s %-5s %-4s %s", fmt.Sprintf("#%d", q.ID), fmt.Sprintf("v%d", q.Revision), difficulty, reports, text)
+		if i == m.cursor {
+			b.WriteString(m.styles.AnswerSelected.Render("▶ "+row) + "\n")
+		} else {
+			b.WriteString(m.styles.LeaderboardRow.Render("  "+row) + "\n")
+		}
+	}
+
+	help := m.styles.Help.Render(m.lang.T("admin.questions_help"))
+	b.WriteString("\n" + help + "\n")
+}
+
+func (m AdminModel) renderForm(b *strings.Builder) {
+	title := m.lang.T("admin.new_question")
+	if m.editing != 0 {
+		title = m.lang.T("admin.edit_question", m.editing)
+	}
+	b.WriteString(m.styles.Title.Render(title) + "\n")
+
+	for i, field := range m.fields {
+		label := m.formLabel(i)
+		line := fmt.Sprintf("%-16s %s", label, field.View())
+		if i == m.focus {
+			b.WriteString(m.styles.LeaderboardTop.Render("▶ "+line) + "\n")
+		} else {
+			b.WriteString(m.styles.LeaderboardRow.Render("  "+line) + "\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render("❌ "+m.err.Error()) + "\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	help := m.styles.Help.Render(m.lang.T("admin.form_help"))
+	b.WriteString(help + "\n")
+}
+
+func (m AdminModel) renderStatus(b *strings.Builder) {
+	if m.status != "" {
+		b.WriteString(m.styles.Stats.Render(m.status) + "\n\n")
+	}
+}
+
+func (m AdminModel) formLabel(field int) string {
+	switch {
+	case field == fieldCategory:
+		return m.lang.T("admin.field_category")
+	case field == fieldText:
+		return m.lang.T("admin.field_text")
+	case field >= fieldOption && field < fieldOption+adminMaxOptions:
+		return m.lang.T("admin.field_option", field-fieldOption+1)
+	case field == fieldAnswer:
+		return m.lang.T("admin.field_answer")
+	case field == fieldExplanation:
+		return m.lang.T("admin.field_explanation")
+	default:
+		return m.lang.T("admin.field_difficulty")
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"quizz-ssh/analytics"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openAnalytics calcule les statistiques de toutes les questions de la banque
+func (m AdminModel) openAnalytics() AdminModel {
+	m.screen = adminAnalytics
+	m.cursor = 0
+	m.status = ""
+
+	answers, err := m.bank.GetRecordedAnswers()
+	if err != nil {
+		m.status = m.lang.T("admin.analytics_failed", err)
+		m.stats = nil
+		return m
+	}
+	m.stats = analytics.Analyze(m.questions, answers)
+	return m
+}
+
+func (m AdminModel) updateAnalytics(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "esc", "q":
+		m.screen = adminCategories
+		m.cursor = 0
+		m.status = ""
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visibleStats())-1 {
+			m.cursor++
+		}
+	case "f":
+		// Ne garder que les questions à revoir
+		m.flaggedOnly = !m.flaggedOnly
+		m.cursor = 0
+	case "enter", "e":
+		stats := m.visibleStats()
+		if len(stats) > 0 {
+			return m.openForm(stats[min(m.cursor, len(stats)-1)].Question, adminAnalytics)
+		}
+	}
+	return m, nil
+}
+
+// visibleStats retourne les statistiques affichées selon le filtre courant
+func (m AdminModel) visibleStats() []analytics.QuestionStats {
+	if m.flaggedOnly {
+		return analytics.Flagged(m.stats)
+	}
+	return m.stats
+}
+
+func (m AdminModel) renderAnalytics(b *strings.Builder) {
+	stats := m.visibleStats()
+	flagged := analytics.Flagged(m.stats)
+	b.WriteString(m.styles.Title.Render(m.lang.T("admin.analytics_title")) + "\n")
+	subtitle := m.lang.T("admin.analytics_count", len(flagged), len(m.stats), analytics.MinAnswers)
+	b.WriteString(m.styles.Subtitle.Render(subtitle) + "\n\n")
+	m.renderStatus(b)
+
+	if len(stats) == 0 {
+		b.WriteString(m.styles.Success.Render(m.lang.T("admin.analytics_clean")) + "\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("admin.analytics_all_help")) + "\n")
+		return
+	}
+
+	headerRow := fmt.Sprintf("%-6s %-6s %-8s %-7s %-7s %s", "#", m.lang.T("admin.col_answers"), m.lang.T("common.col_success"),
+		m.lang.T("common.col_time"), m.lang.T("admin.col_discrimination"), m.lang.T("admin.col_flags"))
+	b.WriteString(m.styles.LeaderboardHeader.Render(headerRow) + "\n\n")
+
+	// Fenêtre de 10 lignes autour du curseur
+	cursor := min(m.cursor, len(stats)-1)
+	start := max(0, min(cursor-5, len(stats)-10))
+	for i := start; i < len(stats) && i < start+10; i++ {
+		s := stats[i]
+		success, avgTime, discrimination := "-", "-", "-"
+		if s.Answers > 0 {
+			success = fmt.Sprintf("%.0f%%", s.SuccessRate*100)
+			avgTime = models.FormatDuration(s.AvgTime)
+			discrimination = fmt.Sprintf("%+.2f", s.Discrimination)
+		}
+		row := fmt.Sprintf("%-6s %-6d %-8s %-7s %-7s %s", fmt.Sprintf("#%d", s.Question.ID), s.Answers,
+			success, avgTime, discrimination, models.Truncate(m.flagSummary(s), 45))
+		if i == cursor {
+			b.WriteString(m.styles.AnswerSelected.Render("▶ "+row) + "\n")
+		} else {
+			b.WriteString(m.styles.LeaderboardRow.Render("  "+row) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	m.renderOptionRates(b, stats[cursor])
+
+	help := m.styles.Help.Render(m.lang.T("admin.analytics_help"))
+	b.WriteString("\n" + help + "\n")
+}
+
+// flagKeys associe à chaque défaut son message dans le catalogue
+var flagKeys = map[analytics.Flag]string{
+	analytics.FlagTooEasy:        "admin.flag_too_easy",
+	analytics.FlagTooHard:        "admin.flag_too_hard",
+	analytics.FlagDeadDistractor: "admin.flag_dead",
+}
+
+// flagSummary traduit les défauts d'une question, avec les lettres des distracteurs morts
+func (m AdminModel) flagSummary(s analytics.QuestionStats) string {
+	var parts []string
+	for _, f := range s.Flags {
+		label := m.lang.T(flagKeys[f])
+		if f == analytics.FlagDeadDistractor {
+			letters := make([]string, len(s.DeadOptions))
+			for i, opt := range s.DeadOptions {
+				letters[i] = string(rune('A' + opt))
+			}
+			label = fmt.Sprintf("%s (%s)", label, strings.Join(letters, ", "))
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderOptionRates affiche la répartition des choix de la question sélectionnée
+func (m AdminModel) renderOptionRates(b *strings.Builder, s analytics.QuestionStats) {
+	b.WriteString(m.styles.Question.Render(models.Truncate(fmt.Sprintf("#%d %s", s.Question.ID, s.Question.Text), 80)) + "\n")
+	dead := make(map[int]bool, len(s.DeadOptions))
+	for _, i := range s.DeadOptions {
+		dead[i] = true
+	}
+	for i, opt := range s.Question.Options {
+		line := fmt.Sprintf("  %c. %-50s %3.0f%% (%d)", 'A'+i, models.Truncate(opt, 50), s.OptionRate(i)*100, s.OptionCounts[i])
+		switch {
+		case i == s.Question.Answer:
+			b.WriteString(m.styles.Success.Render(line+" ✓") + "\n")
+		case dead[i]:
+			b.WriteString(m.styles.Error.Render(line+" ☠") + "\n")
+		default:
+			b.WriteString(m.styles.LeaderboardRow.Render(line) + "\n")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AnnounceMsg est émis quand un administrateur diffuse une annonce; l'application
+// l'envoie à toutes les sessions ouvertes
+type AnnounceMsg struct {
+	Text string
+}
+
+// openAnnounce ouvre la saisie d'une annonce
+func (m AdminModel) openAnnounce() (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Width = 60
+	ti.CharLimit = 200
+	ti.Prompt = "📢 "
+	ti.Placeholder = m.lang.T("admin.announce_placeholder")
+	m.announce = ti
+	m.screen = adminAnnounce
+	m.status = ""
+	m.err = nil
+	return m, m.announce.Focus()
+}
+
+func (m AdminModel) updateAnnounce(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			m.screen = adminCategories
+			m.status = ""
+			return m, nil
+		case "enter":
+			text := strings.TrimSpace(m.announce.Value())
+			if text == "" {
+				m.status = m.lang.T("admin.announce_empty")
+				return m, nil
+			}
+			m.screen = adminCategories
+			m.status = m.lang.T("admin.announce_sent")
+			return m, func() tea.Msg { return AnnounceMsg{Text: text} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.announce, cmd = m.announce.Update(msg)
+	return m, cmd
+}
+
+func (m AdminModel) renderAnnounce(b *strings.Builder) {
+	b.WriteString(m.styles.Title.Render(m.lang.T("admin.announce_title")) + "\n")
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("admin.announce_note")) + "\n\n")
+	m.renderStatus(b)
+	b.WriteString(m.styles.Box.Render(m.announce.View()) + "\n")
+	b.WriteString(m.styles.Help.Render(m.lang.T("admin.announce_help")) + "\n")
+}
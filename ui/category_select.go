@@ -2,21 +2,25 @@ package ui
 
 import (
 	"fmt"
+	"quizz-ssh/i18n"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type CategorySelectModel struct {
+	lang       i18n.Lang
+	styles     *Styles
 	categories []string
 	cursor     int
 	username   string
 	title      string
 }
 
-func NewCategorySelectModel(username string, categories []string, title string) CategorySelectModel {
+func NewCategorySelectModel(lang i18n.Lang, styles *Styles, username string, categories []string, title string) CategorySelectModel {
 	return CategorySelectModel{
+		lang:       lang,
+		styles:     styles,
 		categories: categories,
 		cursor:     0,
 		username:   username,
@@ -30,6 +34,8 @@ func (m CategorySelectModel) Init() tea.Cmd {
 
 func (m CategorySelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q", "esc":
@@ -53,31 +59,31 @@ func (m CategorySelectModel) View() string {
 	var b strings.Builder
 
 	// Header
-	header := HeaderStyle.Render("🔐 CYBERSEC QUIZ 🔐")
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
 	b.WriteString(header + "\n\n")
 
 	// Title
-	title := TitleStyle.Render(m.title)
+	title := m.styles.Title.Render(m.title)
 	b.WriteString(title + "\n")
 
-	subtitle := SubtitleStyle.Render(fmt.Sprintf("Connecté en tant que: %s", m.username))
+	subtitle := m.styles.Subtitle.Render(m.lang.T("common.logged_in", m.username))
 	b.WriteString(subtitle + "\n\n")
 
 	if len(m.categories) == 0 {
-		b.WriteString(ErrorStyle.Render("❌ Aucune catégorie disponible") + "\n\n")
-		help := HelpStyle.Render("q: retour au menu")
+		b.WriteString(m.styles.Error.Render(m.lang.T("category.none")) + "\n\n")
+		help := m.styles.Help.Render(m.lang.T("common.help_back_menu"))
 		b.WriteString(help + "\n")
-		return lipgloss.NewStyle().Padding(2).Render(b.String())
+		return m.styles.Page.Render(b.String())
 	}
 
 	// Categories
 	for i, cat := range m.categories {
-		badge := CategoryBadgeStyle.Render(cat)
+		badge := m.styles.CategoryBadge.Render(cat)
 		if i == m.cursor {
-			line := MenuItemSelectedStyle.Render(fmt.Sprintf("▶ %s", badge))
+			line := m.styles.MenuItemSelected.Render(fmt.Sprintf("▶ %s", badge))
 			b.WriteString(line + "\n")
 		} else {
-			line := MenuItemStyle.Render(fmt.Sprintf("  %s", badge))
+			line := m.styles.MenuItem.Render(fmt.Sprintf("  %s", badge))
 			b.WriteString(line + "\n")
 		}
 		b.WriteString("\n")
@@ -85,10 +91,10 @@ func (m CategorySelectModel) View() string {
 
 	// Help
 	b.WriteString("\n")
-	help := HelpStyle.Render("↑/↓ ou j/k: naviguer • enter: sélectionner • q: retour")
+	help := m.styles.Help.Render(m.lang.T("category.help"))
 	b.WriteString(help + "\n")
 
-	return lipgloss.NewStyle().Padding(2).Render(b.String())
+	return m.styles.Page.Render(b.String())
 }
 
 func (m CategorySelectModel) GetSelectedCategory() string {
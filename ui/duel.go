@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"quizz-ssh/i18n"
+	"quizz-ssh/lobby"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DuelLobbyModel liste les joueurs connectés pouvant être défiés
+type DuelLobbyModel struct {
+	lang     i18n.Lang
+	styles   *Styles
+	username string
+	players  []lobby.PlayerInfo
+	record   models.DuelRecord
+	cursor   int
+	selected bool
+	done     bool
+}
+
+func NewDuelLobbyModel(lang i18n.Lang, styles *Styles, username string, players []lobby.PlayerInfo, record models.DuelRecord) DuelLobbyModel {
+	return DuelLobbyModel{
+		lang:     lang,
+		styles:   styles,
+		username: username,
+		players:  players,
+		record:   record,
+	}
+}
+
+func (m DuelLobbyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m DuelLobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "q", "esc":
+			m.done = true
+			return m, nil
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.players)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			if len(m.players) > 0 {
+				m.selected = true
+				m.done = true
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m DuelLobbyModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	b.WriteString(m.styles.Title.Render(m.lang.T("duel.lobby_title")) + "\n")
+
+	subtitle := m.styles.Subtitle.Render(m.lang.T("common.logged_in", m.username))
+	b.WriteString(subtitle + "\n")
+
+	record := m.lang.T("duel.record", m.record.Wins, m.record.Losses, m.record.Draws)
+	b.WriteString(m.styles.Stats.Render(record) + "\n\n")
+
+	if len(m.players) == 0 {
+		b.WriteString(m.styles.Error.Render(m.lang.T("duel.no_players")) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("duel.auto_refresh")) + "\n")
+	} else {
+		for i, p := range m.players {
+			if i == m.cursor {
+				b.WriteString(m.styles.MenuItemSelected.Render("▶ "+p.Username) + "\n")
+			} else {
+				b.WriteString(m.styles.MenuItem.Render("  "+p.Username) + "\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	help := m.styles.Help.Render(m.lang.T("duel.lobby_help"))
+	b.WriteString(help + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+// SetPlayers remplace la liste des adversaires disponibles
+func (m DuelLobbyModel) SetPlayers(players []lobby.PlayerInfo) DuelLobbyModel {
+	m.players = players
+	if m.cursor >= len(players) {
+		m.cursor = max(len(players)-1, 0)
+	}
+	return m
+}
+
+// GetSelected retourne l'adversaire choisi, s'il y en a un
+func (m DuelLobbyModel) GetSelected() (lobby.PlayerInfo, bool) {
+	if !m.selected || m.cursor >= len(m.players) {
+		return lobby.PlayerInfo{}, false
+	}
+	return m.players[m.cursor], true
+}
+
+func (m DuelLobbyModel) IsDone() bool {
+	return m.done
+}
+
+// DuelPromptModel affiche un défi reçu (accepter/refuser) ou l'attente d'une réponse
+type DuelPromptModel struct {
+	lang     i18n.Lang
+	styles   *Styles
+	username string
+	opponent string
+	invited  bool // true: on a reçu le défi, false: on attend la réponse
+	accepted bool
+	done     bool
+}
+
+func NewDuelInviteModel(lang i18n.Lang, styles *Styles, username, opponent string) DuelPromptModel {
+	return DuelPromptModel{
+		lang:     lang,
+		styles:   styles,
+		username: username,
+		opponent: opponent,
+		invited:  true,
+	}
+}
+
+func NewDuelWaitModel(lang i18n.Lang, styles *Styles, username, opponent string) DuelPromptModel {
+	return DuelPromptModel{
+		lang:     lang,
+		styles:   styles,
+		username: username,
+		opponent: opponent,
+	}
+}
+
+func (m DuelPromptModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m DuelPromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "y", "o", "enter":
+			if m.invited {
+				m.accepted = true
+				m.done = true
+			}
+		case "n", "q", "esc":
+			m.done = true
+		}
+	}
+	return m, nil
+}
+
+func (m DuelPromptModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	if m.invited {
+		b.WriteString(m.styles.Title.Render(m.lang.T("duel.invite_title", m.opponent)) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("duel.invite_rules")) + "\n")
+		help := m.styles.Help.Render(m.lang.T("duel.invite_help"))
+		b.WriteString(help + "\n")
+	} else {
+		b.WriteString(m.styles.Title.Render(m.lang.T("duel.sent")) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("duel.awaiting_answer", m.opponent)) + "\n")
+		help := m.styles.Help.Render(m.lang.T("duel.help_cancel"))
+		b.WriteString(help + "\n")
+	}
+
+	return m.styles.Page.Render(b.String())
+}
+
+func (m DuelPromptModel) IsAccepted() bool {
+	return m.accepted
+}
+
+func (m DuelPromptModel) IsDone() bool {
+	return m.done
+}
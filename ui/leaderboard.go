@@ -2,28 +2,128 @@ package ui
 
 import (
 	"fmt"
+	"quizz-ssh/achievements"
+	"quizz-ssh/i18n"
 	"quizz-ssh/models"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// LeaderboardSource charge le classement page par page
+type LeaderboardSource interface {
+	// Page retourne une page du classement filtrée sur le pseudo et le nombre total de lignes
+	Page(period models.Period, search string, offset, limit int) ([]models.Score, int, error)
+	// UserRank retourne la ligne d'un joueur; ok vaut false s'il n'est pas classé
+	UserRank(period models.Period, username string) (score models.Score, ok bool, err error)
+	// Achievements retourne les codes des badges débloqués par chaque joueur
+	Achievements(usernames []string) (map[string][]string, error)
+}
+
+const leaderboardPageSize = 10
+
+// Onglets de période, dans l'ordre de navigation gauche/droite
+var leaderboardPeriods = []models.Period{
+	models.PeriodDay,
+	models.PeriodWeek,
+	models.PeriodMonth,
+	models.PeriodAll,
+}
+
 type LeaderboardModel struct {
-	username string
-	category string
-	scores   []models.Score
-	stats    string
-	done     bool
+	lang      i18n.Lang
+	styles    *Styles
+	username  string
+	category  string
+	period    models.Period
+	source    LeaderboardSource
+	scores    []models.Score
+	total     int
+	page      int
+	mine      models.Score
+	ranked    bool // le joueur courant apparaît dans le classement de la période
+	badges    map[string][]string
+	search    textinput.Model
+	searching bool
+	err       error
+	games     int // parties jouées, toutes catégories
+	players   int // joueurs distincts
+	done      bool
 }
 
-func NewLeaderboardModel(username, category string, scores []models.Score, stats string) LeaderboardModel {
-	return LeaderboardModel{
+func NewLeaderboardModel(lang i18n.Lang, styles *Styles, username, category string, games, players int, source LeaderboardSource) LeaderboardModel {
+	search := textinput.New()
+	search.Placeholder = lang.T("leaderboard.search_placeholder")
+	search.Prompt = "/ "
+	search.CharLimit = 20
+	search.Width = 20
+
+	m := LeaderboardModel{
+		lang:     lang,
+		styles:   styles,
 		username: username,
 		category: category,
-		scores:   scores,
-		stats:    stats,
+		period:   models.PeriodAll,
+		source:   source,
+		search:   search,
+		games:    games,
+		players:  players,
+	}
+	return m.reload()
+}
+
+// reload recharge la page courante et la position du joueur pour la période
+func (m LeaderboardModel) reload() LeaderboardModel {
+	query := strings.TrimSpace(m.search.Value())
+	m.scores, m.total, m.err = m.source.Page(m.period, query, m.page*leaderboardPageSize, leaderboardPageSize)
+	if m.err != nil {
+		return m
+	}
+	m.mine, m.ranked, m.err = m.source.UserRank(m.period, m.username)
+	if m.err != nil {
+		return m
+	}
+
+	usernames := []string{m.username}
+	for _, score := range m.scores {
+		usernames = append(usernames, score.Username)
+	}
+	// Les badges sont décoratifs: une erreur ne bloque pas l'affichage
+	m.badges, _ = m.source.Achievements(usernames)
+	return m
+}
+
+// shiftPeriod passe à l'onglet de période précédent (-1) ou suivant (+1)
+func (m LeaderboardModel) shiftPeriod(delta int) LeaderboardModel {
+	idx := 0
+	for i, p := range leaderboardPeriods {
+		if p == m.period {
+			idx = i
+		}
 	}
+	idx = (idx + delta + len(leaderboardPeriods)) % len(leaderboardPeriods)
+	m.period = leaderboardPeriods[idx]
+	m.page = 0
+	return m.reload()
+}
+
+// shiftPage change de page en restant dans les bornes
+func (m LeaderboardModel) shiftPage(delta int) LeaderboardModel {
+	page := m.page + delta
+	if page < 0 || page > m.lastPage() {
+		return m
+	}
+	m.page = page
+	return m.reload()
+}
+
+func (m LeaderboardModel) lastPage() int {
+	if m.total == 0 {
+		return 0
+	}
+	return (m.total - 1) / leaderboardPageSize
 }
 
 func (m LeaderboardModel) Init() tea.Cmd {
@@ -31,6 +131,13 @@ func (m LeaderboardModel) Init() tea.Cmd {
 }
 
 func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.styles = m.styles.Resize(size.Width, size.Height)
+	}
+	if m.searching {
+		return m.updateSearch(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -39,11 +146,51 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "left", "h":
+			return m.shiftPeriod(-1), nil
+		case "right", "l", "tab":
+			return m.shiftPeriod(1), nil
+		case "pgdown", "n":
+			return m.shiftPage(1), nil
+		case "pgup", "p":
+			return m.shiftPage(-1), nil
+		case "/":
+			m.searching = true
+			return m, m.search.Focus()
 		}
 	}
 	return m, nil
 }
 
+// updateSearch gère la saisie du filtre: le classement se met à jour à chaque frappe
+func (m LeaderboardModel) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			m.searching = false
+			m.search.Blur()
+			return m, nil
+		case tea.KeyEsc:
+			m.searching = false
+			m.search.Blur()
+			m.search.SetValue("")
+			m.page = 0
+			return m.reload(), nil
+		}
+	}
+
+	previous := m.search.Value()
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	if m.search.Value() != previous {
+		m.page = 0
+		m = m.reload()
+	}
+	return m, cmd
+}
+
 func (m LeaderboardModel) IsDone() bool {
 	return m.done
 }
@@ -52,74 +199,140 @@ func (m LeaderboardModel) View() string {
 	var b strings.Builder
 
 	// Header
-	header := HeaderStyle.Render("🔐 CYBERSEC QUIZ 🔐")
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
 	b.WriteString(header + "\n\n")
 
 	// Title
 	var title string
 	if m.category == "" || m.category == "global" {
-		title = "🏆 Leaderboard Global"
+		title = m.lang.T("leaderboard.title_global")
 	} else {
-		title = fmt.Sprintf("📊 Leaderboard - %s", m.category)
+		title = m.lang.T("leaderboard.title_category", m.category)
 	}
 
-	b.WriteString(TitleStyle.Render(title) + "\n")
+	b.WriteString(m.styles.Title.Render(title) + "\n")
 
-	subtitle := SubtitleStyle.Render(fmt.Sprintf("Connecté en tant que: %s", m.username))
+	subtitle := m.styles.Subtitle.Render(m.lang.T("common.logged_in", m.username))
 	b.WriteString(subtitle + "\n\n")
 
-	if len(m.scores) == 0 {
-		b.WriteString(ErrorStyle.Render("❌ Aucun score enregistré pour l'instant") + "\n\n")
-		b.WriteString(SubtitleStyle.Render("Sois le premier à jouer ! 🎮") + "\n\n")
+	b.WriteString(m.renderTabs() + "\n\n")
+
+	if m.searching || m.search.Value() != "" {
+		b.WriteString(m.search.View() + "\n\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render(m.lang.T("leaderboard.error")) + "\n\n")
+	} else if len(m.scores) == 0 && m.search.Value() != "" {
+		b.WriteString(m.styles.Error.Render(m.lang.T("leaderboard.no_match")) + "\n\n")
+	} else if len(m.scores) == 0 {
+		b.WriteString(m.styles.Error.Render(m.lang.T("leaderboard.empty")) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("leaderboard.be_first")) + "\n\n")
 	} else {
 		// Stats
-		if m.stats != "" {
-			b.WriteString(StatsStyle.Render(m.stats) + "\n\n")
+		if m.games > 0 {
+			b.WriteString(m.styles.Stats.Render(m.lang.T("leaderboard.stats", m.games, m.players)) + "\n\n")
 		}
 
 		// Table header
-		headerRow := fmt.Sprintf("%-5s %-20s %-15s %-10s", "Rank", "Pseudo", "Score", "Réussite")
-		b.WriteString(LeaderboardHeaderStyle.Render(headerRow) + "\n\n")
-
-		// Scores
-		for i, score := range m.scores {
-			rank := fmt.Sprintf("#%d", i+1)
-			percentage := float64(score.Score) / float64(score.Total) * 100
-			scoreText := fmt.Sprintf("%d/%d", score.Score, score.Total)
-			successRate := fmt.Sprintf("%.1f%%", percentage)
-
-			row := fmt.Sprintf("%-5s %-20s %-15s %-10s", rank, score.Username, scoreText, successRate)
-
-			var style lipgloss.Style
-			if i == 0 {
-				// Premier place
-				row = "🥇 " + row
-				style = LeaderboardTopStyle
-			} else if i == 1 {
-				// Deuxième place
-				row = "🥈 " + row
-				style = LeaderboardTopStyle
-			} else if i == 2 {
-				// Troisième place
-				row = "🥉 " + row
-				style = LeaderboardTopStyle
-			} else {
-				row = "   " + row
-				style = LeaderboardRowStyle
-			}
+		headerRow := fmt.Sprintf("%-5s %-28s %-15s %-10s %-8s", m.lang.T("common.col_rank"), m.lang.T("common.col_player"),
+			m.lang.T("common.col_score"), m.lang.T("common.col_success"), m.lang.T("common.col_time"))
+		b.WriteString(m.styles.LeaderboardHeader.Render(headerRow) + "\n\n")
 
-			// Highlight current user
+		// Scores (les ex-aequo partagent le même rang)
+		visible := false
+		for _, score := range m.scores {
 			if score.Username == m.username {
-				style = style.Copy().Foreground(accentColor).Bold(true)
+				visible = true
 			}
+			b.WriteString(m.renderRow(score) + "\n")
+		}
 
-			b.WriteString(style.Render(row) + "\n")
+		// Ligne du joueur épinglée quand elle n'est pas sur la page
+		if !visible {
+			b.WriteString(m.styles.LeaderboardRow.Render("   ⋯") + "\n")
+			if m.ranked {
+				b.WriteString(m.renderRow(m.mine) + "\n")
+			} else {
+				b.WriteString(m.styles.Help.Render("   "+m.lang.T("leaderboard.unranked")) + "\n")
+			}
 		}
+
+		pageInfo := m.lang.T("leaderboard.page", m.page+1, m.lastPage()+1, m.total)
+		b.WriteString("\n" + m.styles.Stats.Render(pageInfo) + "\n")
 	}
 
 	b.WriteString("\n")
-	help := HelpStyle.Render("q ou enter: retour au menu")
-	b.WriteString(help + "\n")
+	var help string
+	if m.searching {
+		help = m.lang.T("leaderboard.help_search")
+	} else {
+		help = m.lang.T("leaderboard.help")
+	}
+	b.WriteString(m.styles.Help.Render(help) + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+func (m LeaderboardModel) renderRow(score models.Score) string {
+	rank := fmt.Sprintf("#%d", score.Rank)
+	percentage := 0.0
+	if score.Total > 0 {
+		percentage = float64(score.Score) / float64(score.Total) * 100
+	}
+	scoreText := fmt.Sprintf("%d/%d", score.Score, score.Total)
+	successRate := fmt.Sprintf("%.1f%%", percentage)
 
-	return lipgloss.NewStyle().Padding(2).Render(b.String())
+	name := score.Username
+	if icons := achievements.Icons(m.badges[score.Username]); icons != "" {
+		name += " " + icons
+	}
+
+	row := fmt.Sprintf("%-5s %s %-15s %-10s %-8s", rank, padRight(name, 28), scoreText, successRate, models.FormatDuration(score.Duration))
+
+	var style lipgloss.Style
+	if score.Rank == 1 {
+		// Premier place
+		row = "🥇 " + row
+		style = m.styles.LeaderboardTop
+	} else if score.Rank == 2 {
+		// Deuxième place
+		row = "🥈 " + row
+		style = m.styles.LeaderboardTop
+	} else if score.Rank == 3 {
+		// Troisième place
+		row = "🥉 " + row
+		style = m.styles.LeaderboardTop
+	} else {
+		row = "   " + row
+		style = m.styles.LeaderboardRow
+	}
+
+	// Highlight current user
+	if score.Username == m.username {
+		row += " ◀"
+		style = m.styles.Highlight(style)
+	}
+
+	return style.Render(row)
+}
+
+func (m LeaderboardModel) renderTabs() string {
+	tabs := make([]string, len(leaderboardPeriods))
+	for i, p := range leaderboardPeriods {
+		if p == m.period {
+			tabs[i] = m.styles.Selected.Render(p.Label(m.lang))
+		} else {
+			tabs[i] = m.styles.Unselected.Render(p.Label(m.lang))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// padRight complète s avec des espaces jusqu'à la largeur affichée (les emojis comptent double)
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
 }
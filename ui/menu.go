@@ -1,11 +1,10 @@
 package ui
 
 import (
-	"fmt"
+	"quizz-ssh/i18n"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type MenuChoice int
@@ -13,23 +12,47 @@ type MenuChoice int
 const (
 	MenuQuiz MenuChoice = iota
 	MenuLeaderboard
+	MenuRating
+	MenuProfile
+	MenuDuel
+	MenuSettings
+	MenuAdmin
 	MenuQuit
 )
 
+type menuItem struct {
+	label  string
+	choice MenuChoice
+}
+
 type MenuModel struct {
-	choices  []string
+	lang     i18n.Lang
+	styles   *Styles
+	choices  []menuItem
 	cursor   int
 	username string
 	done     bool
 }
 
-func NewMenuModel(username string) MenuModel {
+// NewMenuModel crée le menu principal; l'administration n'apparaît que pour les administrateurs
+func NewMenuModel(lang i18n.Lang, styles *Styles, username string, admin bool) MenuModel {
+	choices := []menuItem{
+		{lang.T("menu.play"), MenuQuiz},
+		{lang.T("menu.leaderboard"), MenuLeaderboard},
+		{lang.T("menu.rating"), MenuRating},
+		{lang.T("menu.profile"), MenuProfile},
+		{lang.T("menu.duel"), MenuDuel},
+		{lang.T("menu.settings"), MenuSettings},
+	}
+	if admin {
+		choices = append(choices, menuItem{lang.T("menu.admin"), MenuAdmin})
+	}
+	choices = append(choices, menuItem{lang.T("menu.quit"), MenuQuit})
+
 	return MenuModel{
-		choices: []string{
-			"🎯 Jouer au Quiz",
-			"🏆 Leaderboard",
-			"🚪 Quitter",
-		},
+		lang:     lang,
+		styles:   styles,
+		choices:  choices,
 		cursor:   0,
 		username: username,
 	}
@@ -41,13 +64,15 @@ func (m MenuModel) Init() tea.Cmd {
 
 func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
 		case "q":
 			// Si on appuie sur q, c'est pour quitter
-			m.cursor = int(MenuQuit)
+			m.cursor = len(m.choices) - 1
 			m.done = true
 			return m, nil
 		case "up", "k":
@@ -70,36 +95,38 @@ func (m MenuModel) View() string {
 	var b strings.Builder
 
 	// Header
-	header := HeaderStyle.Render("🔐 CYBERSEC QUIZ 🔐")
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
 	b.WriteString(header + "\n\n")
 
 	// User greeting
-	greeting := TitleStyle.Render(fmt.Sprintf("Salut, %s ! 👋", m.username))
+	greeting := m.styles.Title.Render(m.lang.T("menu.greeting", m.username))
 	b.WriteString(greeting + "\n")
 
-	subtitle := SubtitleStyle.Render("Choisis une option pour continuer")
+	subtitle := m.styles.Subtitle.Render(m.lang.T("menu.subtitle"))
 	b.WriteString(subtitle + "\n\n")
 
 	// Menu items
 	for i, choice := range m.choices {
 		if i == m.cursor {
-			b.WriteString(MenuItemSelectedStyle.Render("▶ "+choice) + "\n")
+			b.WriteString(m.styles.MenuItemSelected.Render("▶ "+choice.label) + "\n")
 		} else {
-			b.WriteString(MenuItemStyle.Render("  "+choice) + "\n")
+			b.WriteString(m.styles.MenuItem.Render("  "+choice.label) + "\n")
+		}
+		if !m.styles.Compact() {
+			b.WriteString("\n")
 		}
-		b.WriteString("\n")
 	}
 
 	// Help
 	b.WriteString("\n")
-	help := HelpStyle.Render("↑/↓ ou j/k: naviguer • enter: sélectionner • q: quitter")
+	help := m.styles.Help.Render(m.lang.T("menu.help"))
 	b.WriteString(help + "\n")
 
-	return lipgloss.NewStyle().Padding(2).Render(b.String())
+	return m.styles.Page.Render(b.String())
 }
 
 func (m MenuModel) GetChoice() MenuChoice {
-	return MenuChoice(m.cursor)
+	return m.choices[m.cursor].choice
 }
 
 func (m MenuModel) IsDone() bool {
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Issues d'un signalement, enregistrées avec lui à sa clôture
+const (
+	resolutionFixed    = "corrigée"
+	resolutionDisabled = "désactivée"
+	resolutionDismiss  = "classée sans suite"
+)
+
+// flaggedQuestions retourne les questions ayant des signalements en attente,
+// dans l'ordre de leur plus ancien signalement
+func (m AdminModel) flaggedQuestions() []models.Question {
+	byID := make(map[int]models.Question, len(m.questions))
+	for _, q := range m.questions {
+		byID[q.ID] = q
+	}
+
+	var flagged []models.Question
+	seen := make(map[int]bool)
+	for _, r := range m.reports {
+		if seen[r.QuestionID] {
+			continue
+		}
+		seen[r.QuestionID] = true
+		q, ok := byID[r.QuestionID]
+		if !ok {
+			// Question supprimée depuis le signalement
+			q = models.Question{ID: r.QuestionID, Text: m.lang.T("admin.deleted_question")}
+		}
+		flagged = append(flagged, q)
+	}
+	return flagged
+}
+
+func (m AdminModel) updateReports(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	flagged := m.flaggedQuestions()
+	switch key.String() {
+	case "esc", "q":
+		m.screen = adminCategories
+		m.cursor = 0
+		m.status = ""
+		return m, nil
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.cursor < len(flagged)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	if len(flagged) == 0 {
+		return m, nil
+	}
+	selected := flagged[min(m.cursor, len(flagged)-1)]
+	exists := selected.Revision > 0
+
+	switch key.String() {
+	case "enter", "e":
+		if exists {
+			return m.openForm(selected, adminReports)
+		}
+	case "p":
+		if exists {
+			m.preview = selected
+			m.previewResult = true
+			m.previewFrom = adminReports
+			m.screen = adminPreview
+		}
+	case "x":
+		if exists {
+			m = m.setDisabled(selected, true)
+			m = m.resolve(selected.ID, resolutionDisabled)
+		}
+	case "r":
+		m = m.resolve(selected.ID, resolutionDismiss)
+	}
+	return m, nil
+}
+
+// resolve clôt les signalements d'une question et recharge la file
+func (m AdminModel) resolve(questionID int, resolution string) AdminModel {
+	n, err := m.bank.ResolveReports(questionID, resolution)
+	if err != nil {
+		m.status = m.lang.T("admin.close_failed", err)
+		return m
+	}
+	if resolution != resolutionDisabled {
+		m.status = m.lang.T("admin.resolved", n, questionID)
+	}
+	m = m.reload()
+	m.cursor = max(0, min(m.cursor, len(m.flaggedQuestions())-1))
+	return m
+}
+
+func (m AdminModel) renderReports(b *strings.Builder) {
+	flagged := m.flaggedQuestions()
+	b.WriteString(m.styles.Title.Render(m.lang.T("admin.reports_title")) + "\n")
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("admin.reports_count", len(m.reports), len(flagged))) + "\n\n")
+	m.renderStatus(b)
+
+	if len(flagged) == 0 {
+		b.WriteString(m.styles.Success.Render(m.lang.T("admin.reports_empty")) + "\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("common.help_back")) + "\n")
+		return
+	}
+
+	for i, q := range flagged {
+		row := fmt.Sprintf("#%-5d 🚩%-3d %s", q.ID, m.reportCounts[q.ID], models.Truncate(q.Text, 55))
+		if q.Disabled {
+			row = fmt.Sprintf("#%-5d 🚩%-3d ⛔ %s", q.ID, m.reportCounts[q.ID], models.Truncate(q.Text, 52))
+		}
+		if i != m.cursor {
+			b.WriteString(m.styles.LeaderboardRow.Render("  "+row) + "\n")
+			continue
+		}
+
+		b.WriteString(m.styles.AnswerSelected.Render("▶ "+row) + "\n")
+		for _, r := range m.reports {
+			if r.QuestionID != q.ID {
+				continue
+			}
+			detail := fmt.Sprintf("%s • %s (v%d): %s", r.CreatedAt.Local().Format(m.lang.T("format.datetime")), r.Username, r.Revision, r.Reason)
+			b.WriteString(m.styles.Stats.Render("    "+models.Truncate(detail, 90)) + "\n")
+		}
+	}
+
+	help := m.styles.Help.Render(m.lang.T("admin.reports_help"))
+	b.WriteString("\n" + help + "\n")
+}
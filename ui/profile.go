@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"quizz-ssh/achievements"
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Nombre de parties détaillées dans le tableau d'historique
+const profileHistoryRows = 8
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// ProfileModel affiche la progression d'un joueur
+type ProfileModel struct {
+	lang    i18n.Lang
+	styles  *Styles
+	profile models.Profile
+	err     error
+	done    bool
+}
+
+func NewProfileModel(lang i18n.Lang, styles *Styles, profile models.Profile, err error) ProfileModel {
+	return ProfileModel{
+		lang:    lang,
+		styles:  styles,
+		profile: profile,
+		err:     err,
+	}
+}
+
+func (m ProfileModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ProfileModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", " ", "esc":
+			m.done = true
+			return m, nil
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m ProfileModel) IsDone() bool {
+	return m.done
+}
+
+func (m ProfileModel) View() string {
+	var b strings.Builder
+	p := m.profile
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	b.WriteString(m.styles.Title.Render(m.lang.T("profile.title", p.Username)) + "\n")
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render(m.lang.T("profile.error")) + "\n\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("common.help_enter_menu")) + "\n")
+		return m.styles.Page.Render(b.String())
+	}
+
+	if p.Stats.Attempts == 0 {
+		b.WriteString(m.styles.Error.Render(m.lang.T("profile.empty")) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("profile.empty_hint")) + "\n\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("common.help_enter_menu")) + "\n")
+		return m.styles.Page.Render(b.String())
+	}
+
+	// Résumé
+	summary := m.lang.T("profile.summary",
+		p.Stats.Attempts, p.Stats.SuccessRate(), formatPlayTime(p.Stats.TotalTime),
+		p.Rating.Rating, p.Duels.Wins, p.Duels.Losses, p.Duels.Draws,
+	)
+	b.WriteString(m.styles.Box.Render(m.styles.Question.Render(summary)) + "\n")
+
+	// Sparkline des dernières parties (de la plus ancienne à la plus récente)
+	if len(p.History) > 1 {
+		percentages := make([]float64, len(p.History))
+		for i, s := range p.History {
+			percentages[len(p.History)-1-i] = scorePercentage(s)
+		}
+		label := m.styles.Stats.Render(m.lang.T("profile.sparkline", len(p.History)))
+		b.WriteString(label + " " + m.renderSparkline(percentages) + "\n\n")
+	}
+
+	// Badges: débloqués en couleur avec leur date, les autres grisés
+	unlocked := make(map[string]models.UserAchievement, len(p.Achievements))
+	for _, a := range p.Achievements {
+		unlocked[a.Code] = a
+	}
+	b.WriteString(m.styles.LeaderboardHeader.Render(m.lang.T("profile.badges", len(unlocked), len(achievements.All))) + "\n\n")
+	for _, a := range achievements.All {
+		name, description := achievementText(m.lang, a)
+		if u, ok := unlocked[a.Code]; ok {
+			date := m.lang.T("profile.unlocked", u.UnlockedAt.Local().Format(m.lang.T("format.date")))
+			line := fmt.Sprintf("%s %-14s %s %s", a.Icon, name, description, date)
+			b.WriteString(m.styles.LeaderboardTop.Render(line) + "\n")
+		} else {
+			line := fmt.Sprintf("🔒 %-14s %s", name, description)
+			b.WriteString(m.styles.Unselected.Render(line) + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	// Meilleur score par catégorie
+	b.WriteString(m.styles.LeaderboardHeader.Render(fmt.Sprintf("%-20s %-10s %-10s %-8s", m.lang.T("common.col_category"),
+		m.lang.T("profile.col_best"), m.lang.T("common.col_success"), m.lang.T("common.col_time"))) + "\n\n")
+	for _, s := range p.Best {
+		row := fmt.Sprintf("%-20s %-10s %-10s %-8s",
+			models.Truncate(s.Category, 20), fmt.Sprintf("%d/%d", s.Score, s.Total),
+			fmt.Sprintf("%.1f%%", scorePercentage(s)), models.FormatDuration(s.Duration))
+		b.WriteString(m.styles.LeaderboardRow.Render(row) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Dernières parties
+	b.WriteString(m.styles.LeaderboardHeader.Render(fmt.Sprintf("%-12s %-20s %-10s %-10s", m.lang.T("common.col_date"),
+		m.lang.T("common.col_category"), m.lang.T("common.col_score"), m.lang.T("common.col_success"))) + "\n\n")
+	for i, s := range p.History {
+		if i >= profileHistoryRows {
+			break
+		}
+		row := fmt.Sprintf("%-12s %-20s %-10s %-10s",
+			s.CreatedAt.Local().Format(m.lang.T("format.datetime")), models.Truncate(s.Category, 20),
+			fmt.Sprintf("%d/%d", s.Score, s.Total), fmt.Sprintf("%.1f%%", scorePercentage(s)))
+		b.WriteString(m.styles.LeaderboardRow.Render(row) + "\n")
+	}
+
+	b.WriteString("\n")
+	help := m.styles.Help.Render(m.lang.T("common.help_enter_menu"))
+	b.WriteString(help + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+// achievementText traduit le nom et la description d'un badge, à défaut ceux du catalogue de badges
+func achievementText(lang i18n.Lang, a achievements.Achievement) (name, description string) {
+	name, description = lang.T("achievement."+a.Code+".name"), lang.T("achievement."+a.Code+".description")
+	if name == "achievement."+a.Code+".name" {
+		return a.Name, a.Description
+	}
+	return name, description
+}
+
+func scorePercentage(s models.Score) float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Score) / float64(s.Total) * 100
+}
+
+// renderSparkline dessine une série de pourcentages, chaque barre colorée selon le résultat
+func (m ProfileModel) renderSparkline(percentages []float64) string {
+	var b strings.Builder
+	for _, pct := range percentages {
+		idx := int(pct / 100 * float64(len(sparkBlocks)-1))
+		idx = max(0, min(idx, len(sparkBlocks)-1))
+
+		color := m.styles.Theme.Error
+		if pct >= 80 {
+			color = m.styles.Theme.Success
+		} else if pct >= 50 {
+			color = m.styles.Theme.Warning
+		}
+		b.WriteString(m.styles.Color(color).Render(string(sparkBlocks[idx])))
+	}
+	return b.String()
+}
+
+// formatPlayTime affiche un temps de jeu cumulé en heures et minutes
+func formatPlayTime(d time.Duration) string {
+	if d < time.Hour {
+		return models.FormatDuration(d)
+	}
+	return fmt.Sprintf("%dh%02d", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -3,10 +3,14 @@ package ui
 import (
 	"fmt"
 	"math/rand"
+	"quizz-ssh/achievements"
+	"quizz-ssh/i18n"
+	"quizz-ssh/lobby"
 	"quizz-ssh/models"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -21,6 +25,8 @@ const (
 )
 
 type QuizModel struct {
+	lang          i18n.Lang
+	styles        *Styles
 	username      string
 	questions     []models.Question
 	currentIndex  int
@@ -33,16 +39,62 @@ type QuizModel struct {
 	showResult    bool
 	resultTime    time.Time
 	done          bool
+	confirm       bool // une réponse choisie doit être confirmée avant d'être validée
+	resumed       bool // partie interrompue reprise en cours de route
+	pending       bool // l'option sous le curseur attend sa confirmation
+
+	answers       []models.Answer
+	questionStart time.Time
+	unlocked      []achievements.Achievement
+
+	// Signalement de la question affichée
+	reporting   bool
+	reportInput textinput.Model
+	reported    map[int]bool // questions déjà signalées pendant ce quiz
+
+	// Duel: progression de l'adversaire
+	opponent      string
+	opponentDone  int
+	opponentScore int
+	duelResult    *lobby.Result
 }
 
-func NewQuizModel(username string, questions []models.Question, category string) QuizModel {
-	// Shuffle les réponses de chaque question
+// NewQuizModel crée un quiz solo: la graine fixe le mélange des options, pour
+// pouvoir reconstruire la même partie si elle est reprise après une déconnexion
+func NewQuizModel(lang i18n.Lang, styles *Styles, username string, questions []models.Question, category string, seed int64) QuizModel {
+	return newQuizModel(lang, styles, username, questions, category, rand.New(rand.NewSource(seed)))
+}
+
+// NewDuelQuizModel crée un quiz de duel: la graine commune garantit aux deux
+// joueurs les mêmes questions dans le même ordre, avec les mêmes options
+func NewDuelQuizModel(lang i18n.Lang, styles *Styles, username string, questions []models.Question, category string, seed int64, count int, opponent string) QuizModel {
+	rng := rand.New(rand.NewSource(seed))
+
+	picked := make([]models.Question, len(questions))
+	copy(picked, questions)
+	rng.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
+	if count > 0 && count < len(picked) {
+		picked = picked[:count]
+	}
+
+	m := newQuizModel(lang, styles, username, picked, category, rng)
+	m.opponent = opponent
+	return m
+}
+
+func newQuizModel(lang i18n.Lang, styles *Styles, username string, questions []models.Question, category string, rng *rand.Rand) QuizModel {
+	// Traduire puis shuffle les réponses de chaque question (l'ordre ne dépend pas de la langue)
 	shuffledQuestions := make([]models.Question, len(questions))
 	for i, q := range questions {
-		shuffledQuestions[i] = shuffleQuestion(q)
+		q = q.Localize(string(lang), string(i18n.Default))
+		shuffledQuestions[i] = shuffleQuestion(q, rng)
 	}
 
 	return QuizModel{
+		lang:         lang,
+		styles:       styles,
 		username:     username,
 		questions:    shuffledQuestions,
 		currentIndex: 0,
@@ -54,7 +106,7 @@ func NewQuizModel(username string, questions []models.Question, category string)
 }
 
 // shuffleQuestion mélange les options d'une question et track la bonne réponse
-func shuffleQuestion(q models.Question) models.Question {
+func shuffleQuestion(q models.Question, rng *rand.Rand) models.Question {
 	// Créer une copie de la question
 	shuffled := q
 
@@ -63,9 +115,6 @@ func shuffleQuestion(q models.Question) models.Question {
 		return shuffled
 	}
 
-	// Créer un générateur aléatoire avec seed unique
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-
 	// Créer un slice avec les indices
 	indices := make([]int, len(q.Options))
 	for i := range indices {
@@ -79,6 +128,7 @@ func shuffleQuestion(q models.Question) models.Question {
 
 	// Créer les nouvelles options mélangées
 	shuffled.ShuffledOptions = make([]string, len(q.Options))
+	shuffled.ShuffleOrder = indices
 	for newIdx, oldIdx := range indices {
 		shuffled.ShuffledOptions[newIdx] = q.Options[oldIdx]
 		// Si c'était la bonne réponse, on note sa nouvelle position
@@ -90,10 +140,23 @@ func shuffleQuestion(q models.Question) models.Question {
 	return shuffled
 }
 
+// originalIndex retrouve l'index d'origine d'une option mélangée
+func originalIndex(q models.Question, shuffledIdx int) int {
+	if shuffledIdx < 0 || shuffledIdx >= len(q.ShuffleOrder) {
+		return shuffledIdx
+	}
+	return q.ShuffleOrder[shuffledIdx]
+}
+
 func (m QuizModel) Init() tea.Cmd {
 	return nil
 }
 
+// ReportMsg est émis quand le joueur signale une question; l'application l'enregistre
+type ReportMsg struct {
+	Report models.Report
+}
+
 type resultTimeoutMsg struct{}
 
 func waitForResult() tea.Cmd {
@@ -103,6 +166,13 @@ func waitForResult() tea.Cmd {
 }
 
 func (m QuizModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.styles = m.styles.Resize(size.Width, size.Height)
+	}
+	if m.reporting {
+		return m.updateReport(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch m.state {
@@ -113,6 +183,7 @@ func (m QuizModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			default:
 				m.state = QuizStateQuestion
+				m.questionStart = time.Now()
 				return m, nil
 			}
 
@@ -123,31 +194,38 @@ func (m QuizModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "up", "k":
 				if m.cursor > 0 {
 					m.cursor--
+					m.pending = false
 				}
 			case "down", "j":
 				if m.cursor < len(m.questions[m.currentIndex].ShuffledOptions)-1 {
 					m.cursor++
+					m.pending = false
 				}
+			case "esc":
+				m.pending = false
 			case "enter", " ":
-				m.userAnswer = m.cursor
-				m.correctAnswer = m.questions[m.currentIndex].ShuffledAnswer
-				if m.userAnswer == m.correctAnswer {
-					m.score++
+				return m.choose(m.cursor)
+			default:
+				// Réponse directe: a, b, c... ou 1, 2, 3...
+				if i, ok := optionKey(msg.String(), len(m.questions[m.currentIndex].ShuffledOptions)); ok {
+					return m.choose(i)
 				}
-				m.state = QuizStateResult
-				m.showResult = true
-				return m, nil
 			}
 
 		case QuizStateResult:
 			// Appuyer sur Enter pour passer à la question suivante
 			switch msg.String() {
+			case "s":
+				if !m.reported[m.questions[m.currentIndex].ID] {
+					return m.startReport()
+				}
 			case "enter", " ":
 				m.currentIndex++
 				if m.currentIndex >= len(m.questions) {
 					m.state = QuizStateFinished
 				} else {
 					m.state = QuizStateQuestion
+					m.questionStart = time.Now()
 					m.cursor = 0
 					m.showResult = false
 				}
@@ -165,71 +243,206 @@ func (m QuizModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			}
 		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+		switch m.state {
+		case QuizStateReady:
+			m.state = QuizStateQuestion
+			m.questionStart = time.Now()
+		case QuizStateQuestion:
+			if i := m.optionAt(msg.Y); i >= 0 {
+				return m.choose(i)
+			}
+		}
 	}
 	return m, nil
 }
 
+// choose répond avec l'option i, ou la sélectionne seulement si le joueur
+// demande une confirmation: la choisir une seconde fois valide la réponse
+func (m QuizModel) choose(i int) (tea.Model, tea.Cmd) {
+	if m.confirm && !(m.pending && m.cursor == i) {
+		m.cursor = i
+		m.pending = true
+		return m, nil
+	}
+	m.cursor = i
+	m.pending = false
+
+	question := m.questions[m.currentIndex]
+	m.userAnswer = m.cursor
+	m.correctAnswer = question.ShuffledAnswer
+	if m.userAnswer == m.correctAnswer {
+		m.score++
+	}
+	m.answers = append(m.answers, models.Answer{
+		QuestionID: question.ID,
+		Revision:   question.Revision,
+		Chosen:     originalIndex(question, m.userAnswer),
+		Correct:    m.userAnswer == m.correctAnswer,
+		Duration:   time.Since(m.questionStart),
+	})
+	m.state = QuizStateResult
+	m.showResult = true
+	return m, nil
+}
+
+// optionKey traduit une touche a, b, c... ou 1, 2, 3... en index d'option
+func optionKey(key string, n int) (int, bool) {
+	if len(key) != 1 {
+		return 0, false
+	}
+	switch c := strings.ToLower(key)[0]; {
+	case c >= 'a' && c <= 'z' && int(c-'a') < n:
+		return int(c - 'a'), true
+	case c >= '1' && c <= '9' && int(c-'1') < n:
+		return int(c - '1'), true
+	}
+	return 0, false
+}
+
+// startReport ouvre la saisie du motif de signalement
+func (m QuizModel) startReport() (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Placeholder = m.lang.T("quiz.report_placeholder")
+	ti.Prompt = "🚩 "
+	ti.CharLimit = 200
+	ti.Width = 60
+	m.reportInput = ti
+	m.reporting = true
+	return m, m.reportInput.Focus()
+}
+
+// updateReport gère la saisie du motif: enter envoie, esc annule
+func (m QuizModel) updateReport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEsc:
+			m.reporting = false
+			return m, nil
+		case tea.KeyEnter:
+			reason := strings.TrimSpace(m.reportInput.Value())
+			if reason == "" {
+				return m, nil
+			}
+			question := m.questions[m.currentIndex]
+			report := models.Report{
+				QuestionID: question.ID,
+				Revision:   question.Revision,
+				Username:   m.username,
+				Reason:     reason,
+			}
+			if m.reported == nil {
+				m.reported = make(map[int]bool)
+			}
+			m.reported[question.ID] = true
+			m.reporting = false
+			return m, func() tea.Msg { return ReportMsg{Report: report} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.reportInput, cmd = m.reportInput.Update(msg)
+	return m, cmd
+}
+
 func (m QuizModel) View() string {
+	view, _ := m.render()
+	return view
+}
+
+// render construit l'écran. Pendant une question, il retourne aussi la ligne où
+// commence chaque option, suivie de celle qui suit la dernière, pour la souris
+func (m QuizModel) render() (string, []int) {
 	var b strings.Builder
 
 	// Header
-	header := HeaderStyle.Render("🔐 CYBERSEC QUIZ 🔐")
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
 	b.WriteString(header + "\n\n")
 
 	if len(m.questions) == 0 {
-		b.WriteString(ErrorStyle.Render("❌ Aucune question disponible") + "\n\n")
-		help := HelpStyle.Render("q: retour au menu")
+		b.WriteString(m.styles.Error.Render(m.lang.T("quiz.no_questions")) + "\n\n")
+		help := m.styles.Help.Render(m.lang.T("common.help_back_menu"))
 		b.WriteString(help + "\n")
-		return lipgloss.NewStyle().Padding(2).Render(b.String())
+		return m.styles.Page.Render(b.String()), nil
 	}
 
+	var lines []int
 	switch m.state {
 	case QuizStateReady:
 		m.renderReady(&b)
 	case QuizStateQuestion, QuizStateResult:
-		m.renderQuestion(&b)
+		lines = m.renderQuestion(&b)
 	case QuizStateFinished:
 		m.renderFinished(&b)
 	}
 
-	return lipgloss.NewStyle().Padding(2).Render(b.String())
+	for i := range lines {
+		lines[i] += m.styles.Page.GetPaddingTop()
+	}
+	return m.styles.Page.Render(b.String()), lines
+}
+
+// optionAt retourne l'option affichée sur la ligne y de l'écran, -1 si aucune
+func (m QuizModel) optionAt(y int) int {
+	_, lines := m.render()
+	for i := 0; i+1 < len(lines); i++ {
+		if y >= lines[i] && y < lines[i+1] {
+			return i
+		}
+	}
+	return -1
 }
 
 func (m QuizModel) renderReady(b *strings.Builder) {
 	// Title
-	title := TitleStyle.Render("🎮 Prêt à commencer ? 🎮")
+	title := m.styles.Title.Render(m.lang.T("quiz.ready_title"))
 	b.WriteString(title + "\n\n")
 
 	// Info
-	info := fmt.Sprintf("Catégorie: %s\nNombre de questions: %d", m.category, len(m.questions))
-	infoBox := BoxStyle.Render(QuestionStyle.Render(info))
+	info := m.lang.T("quiz.ready_info", m.category, len(m.questions))
+	if m.resumed {
+		info += "\n" + m.lang.T("quiz.ready_resume", m.currentIndex+1, m.score)
+	}
+	infoBox := m.styles.Box.Render(m.styles.Question.Render(info))
 	b.WriteString(infoBox + "\n\n")
 
 	// Instructions colorées
-	instructions := SuccessStyle.Render("⚡ Appuyez sur n'importe quelle touche pour commencer le quiz ⚡")
+	instructions := m.styles.Success.Render(m.lang.T("quiz.ready_start"))
 	b.WriteString(instructions + "\n\n")
 
 	// Help
-	help := HelpStyle.Render("q: retour au menu")
+	help := m.styles.Help.Render(m.lang.T("common.help_back_menu"))
 	b.WriteString(help + "\n")
 }
 
-func (m QuizModel) renderQuestion(b *strings.Builder) {
+func (m QuizModel) renderQuestion(b *strings.Builder) []int {
 	question := m.questions[m.currentIndex]
 
 	// Progress bar
-	progress := fmt.Sprintf("Question %d/%d", m.currentIndex+1, len(m.questions))
+	progress := m.lang.T("quiz.progress", m.currentIndex+1, len(m.questions))
 	progressBar := m.renderProgressBar()
 
-	catBadge := CategoryBadgeStyle.Render(question.Category)
-	scoreBadge := ScoreBadgeStyle.Render(fmt.Sprintf("Score: %d/%d", m.score, m.currentIndex))
+	catBadge := m.styles.CategoryBadge.Render(question.Category)
+	scoreBadge := m.styles.ScoreBadge.Render(m.lang.T("quiz.score", m.score, m.currentIndex))
 
-	info := lipgloss.JoinHorizontal(lipgloss.Left, catBadge, " ", scoreBadge, "  ", StatsStyle.Render(progress))
+	info := lipgloss.JoinHorizontal(lipgloss.Left, catBadge, " ", scoreBadge, "  ", m.styles.Stats.Render(progress))
 	b.WriteString(info + "\n")
-	b.WriteString(progressBar + "\n\n")
+	if m.opponent != "" {
+		b.WriteString(m.renderDuelBars() + "\n\n")
+	} else {
+		b.WriteString(progressBar + "\n\n")
+	}
 
-	// Question
-	questionBox := BoxStyle.Render(QuestionStyle.Render("❓ " + question.Text))
+	// Question, renvoyée à la ligne si elle dépasse la largeur du terminal
+	text := "❓ " + question.Text
+	textWidth := m.styles.ContentWidth(80) - m.styles.Box.GetHorizontalFrameSize() - m.styles.Question.GetHorizontalPadding()
+	questionBox := m.styles.Box.Render(m.styles.Question.Render(wrap(text, textWidth)))
 	b.WriteString(questionBox + "\n\n")
 
 	// Options (utiliser les options shufflées)
@@ -238,111 +451,322 @@ func (m QuizModel) renderQuestion(b *strings.Builder) {
 		options = question.Options // Fallback si pas shufflé
 	}
 
+	optionWidth := m.styles.ContentWidth(80) - m.styles.Answer.GetHorizontalPadding()
+	var lines []int
 	for i, option := range options {
+		lines = append(lines, strings.Count(b.String(), "\n"))
 		var line string
 		prefix := fmt.Sprintf("%c) ", 'A'+i)
 
 		if m.state == QuizStateResult {
-			// Afficher le résultat
+			// Afficher le résultat: symbole et libellé, pour ne pas dépendre des couleurs
 			if i == m.correctAnswer {
-				line = AnswerCorrectStyle.Render(prefix + option + " ✓")
+				line = m.styles.AnswerCorrect.Render(hangingWrap("✓ "+prefix, option+" — "+m.lang.T("quiz.mark_correct"), optionWidth))
 			} else if i == m.userAnswer {
-				line = AnswerWrongStyle.Render(prefix + option + " ✗")
+				line = m.styles.AnswerWrong.Render(hangingWrap("✗ "+prefix, option+" — "+m.lang.T("quiz.mark_chosen"), optionWidth))
 			} else {
-				line = AnswerStyle.Render(prefix + option)
+				line = m.styles.Answer.Render(hangingWrap("  "+prefix, option, optionWidth))
 			}
 		} else {
 			// Mode sélection
 			if i == m.cursor {
-				line = AnswerSelectedStyle.Render("▶ " + prefix + option)
+				line = m.styles.AnswerSelected.Render(hangingWrap("▶ "+prefix, option, optionWidth))
 			} else {
-				line = AnswerStyle.Render("  " + prefix + option)
+				line = m.styles.Answer.Render(hangingWrap("  "+prefix, option, optionWidth))
 			}
 		}
 		b.WriteString(line + "\n")
 	}
+	lines = append(lines, strings.Count(b.String(), "\n"))
 
 	b.WriteString("\n")
 
 	// Result message
 	if m.state == QuizStateResult {
 		if m.userAnswer == m.correctAnswer {
-			msg := SuccessStyle.Render("🎉 Bonne réponse !")
+			msg := m.styles.Success.Render(m.lang.T("quiz.correct"))
 			b.WriteString(msg + "\n\n")
 		} else {
-			msg := ErrorStyle.Render("❌ Mauvaise réponse !")
+			msg := m.styles.Error.Render(m.lang.T("quiz.wrong"))
 			b.WriteString(msg + "\n\n")
 		}
-		help := HelpStyle.Render("enter: question suivante • q: quitter")
+		if question.Explanation != "" {
+			b.WriteString(m.styles.Stats.Render("💡 "+question.Explanation) + "\n\n")
+		}
+		switch {
+		case m.reporting:
+			b.WriteString(m.reportInput.View() + "\n")
+			b.WriteString(m.styles.Help.Render(m.lang.T("quiz.report_help")) + "\n")
+		case m.reported[question.ID]:
+			b.WriteString(m.styles.Success.Render(m.lang.T("quiz.reported")) + "\n")
+			b.WriteString(m.styles.Help.Render(m.lang.T("quiz.help_next")) + "\n")
+		default:
+			help := m.styles.Help.Render(m.lang.T("quiz.help_next_report"))
+			b.WriteString(help + "\n")
+		}
+	} else if m.pending {
+		confirm := m.styles.Stats.Render(m.lang.T("quiz.confirm", 'A'+m.cursor))
+		b.WriteString(confirm + "\n")
+		help := m.styles.Help.Render(m.lang.T("quiz.help_confirm"))
 		b.WriteString(help + "\n")
 	} else {
 		// Help
-		help := HelpStyle.Render("↑/↓ ou j/k: naviguer • enter: valider • q: quitter")
+		n := min(len(options), 9)
+		help := m.styles.Help.Render(m.lang.T("quiz.help_answer", 'a'+n-1, n))
 		b.WriteString(help + "\n")
 	}
+	return lines
+}
+
+// renderQuestionPreview affiche une question telle que les joueurs la verront,
+// avant réponse ou avec la correction (options dans l'ordre d'origine)
+func renderQuestionPreview(lang i18n.Lang, styles *Styles, q models.Question, showResult bool) string {
+	m := QuizModel{
+		lang:          lang,
+		styles:        styles,
+		questions:     []models.Question{q},
+		state:         QuizStateQuestion,
+		userAnswer:    q.Answer,
+		correctAnswer: q.Answer,
+	}
+	if showResult {
+		m.state = QuizStateResult
+	}
+
+	var b strings.Builder
+	m.renderQuestion(&b)
+	return b.String()
 }
 
 func (m QuizModel) renderFinished(b *strings.Builder) {
 	// Title
-	title := TitleStyle.Render("🎊 Quiz Terminé ! 🎊")
+	title := m.styles.Title.Render(m.lang.T("quiz.finished"))
 	b.WriteString(title + "\n\n")
 
 	// Score
 	percentage := float64(m.score) / float64(len(m.questions)) * 100
-	scoreText := fmt.Sprintf("Score Final: %d/%d (%.1f%%)", m.score, len(m.questions), percentage)
+	scoreText := m.lang.T("quiz.final_score", m.score, len(m.questions), percentage)
 
 	var scoreStyle lipgloss.Style
 	if percentage >= 80 {
-		scoreStyle = SuccessStyle
+		scoreStyle = m.styles.Success
 	} else if percentage >= 50 {
-		scoreStyle = StatsStyle
+		scoreStyle = m.styles.Stats
 	} else {
-		scoreStyle = ErrorStyle
+		scoreStyle = m.styles.Error
 	}
 
-	scoreBox := BoxStyle.Render(scoreStyle.Render(scoreText))
+	scoreBox := m.styles.Box.Render(scoreStyle.Render(scoreText))
 	b.WriteString(scoreBox + "\n\n")
 
 	// Category
-	catInfo := SubtitleStyle.Render(fmt.Sprintf("Catégorie: %s", m.category))
+	catInfo := m.styles.Subtitle.Render(m.lang.T("quiz.category", m.category))
 	b.WriteString(catInfo + "\n\n")
 
+	// Badges débloqués par cette partie
+	for _, a := range m.unlocked {
+		name, description := achievementText(m.lang, a)
+		toast := m.lang.T("quiz.badge_unlocked", a.Icon, name, description)
+		b.WriteString(m.styles.Toast.Render(toast) + "\n")
+	}
+	if len(m.unlocked) > 0 {
+		b.WriteString("\n")
+	}
+
+	if m.opponent != "" {
+		m.renderDuelOutcome(b)
+		return
+	}
+
 	// Encouragement
 	var encouragement string
 	if percentage == 100 {
-		encouragement = "🏆 Parfait ! Tu es un(e) expert(e) !"
+		encouragement = m.lang.T("quiz.perfect")
 	} else if percentage >= 80 {
-		encouragement = "🌟 Excellent travail !"
+		encouragement = m.lang.T("quiz.excellent")
 	} else if percentage >= 50 {
-		encouragement = "👍 Pas mal, continue comme ça !"
+		encouragement = m.lang.T("quiz.not_bad")
 	} else {
-		encouragement = "💪 Continue à t'entraîner !"
+		encouragement = m.lang.T("quiz.keep_training")
 	}
 
-	b.WriteString(TitleStyle.Render(encouragement) + "\n\n")
+	b.WriteString(m.styles.Title.Render(encouragement) + "\n\n")
 
 	// Help
-	help := HelpStyle.Render("enter ou q: retour au menu")
+	help := m.styles.Help.Render(m.lang.T("common.help_return_menu"))
 	b.WriteString(help + "\n")
 }
 
 func (m QuizModel) renderProgressBar() string {
-	width := 60
-	filled := int(float64(m.currentIndex) / float64(len(m.questions)) * float64(width))
+	return renderBar(m.currentIndex, len(m.questions), m.styles.ContentWidth(60), m.styles.Color(m.styles.Theme.Primary))
+}
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+func renderBar(done, total, width int, style lipgloss.Style) string {
+	filled := 0
+	if total > 0 {
+		filled = min(int(float64(done)/float64(total)*float64(width)), width)
+	}
 
-	style := lipgloss.NewStyle().Foreground(primaryColor)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
 	return style.Render(bar)
 }
 
+// renderDuelBars affiche notre progression et celle de l'adversaire côte à côte
+func (m QuizModel) renderDuelBars() string {
+	total := len(m.questions)
+	label := lipgloss.NewStyle().Width(12)
+	// Le libellé et le score occupent une trentaine de colonnes à côté de la barre
+	width := max(10, min(40, m.styles.ContentWidth(80)-34))
+
+	mine := label.Render(m.lang.T("duel.you")) + renderBar(m.Answered(), total, width, m.styles.Color(m.styles.Theme.Primary)) +
+		m.styles.Stats.Render(fmt.Sprintf("%d/%d", m.Answered(), total))
+	theirs := label.Render(models.Truncate(m.opponent, 11)) + renderBar(m.opponentDone, total, width, m.styles.Color(m.styles.Theme.Secondary)) +
+		m.styles.Stats.Render(m.lang.T("duel.opponent_score", m.opponentDone, total, m.opponentScore))
+
+	return mine + "\n" + theirs
+}
+
+func (m QuizModel) renderDuelOutcome(b *strings.Builder) {
+	if m.duelResult == nil {
+		waiting := m.lang.T("duel.waiting_result", m.opponent, m.opponentDone, len(m.questions))
+		b.WriteString(m.styles.Subtitle.Render(waiting) + "\n\n")
+		b.WriteString(m.styles.Help.Render(m.lang.T("duel.help_waiting")) + "\n")
+		return
+	}
+
+	res := m.duelResult
+	summary := fmt.Sprintf("%s %d - %d %s", res.Players[0], res.Scores[0], res.Scores[1], res.Players[1])
+	b.WriteString(m.styles.Stats.Render(summary) + "\n\n")
+
+	var outcome string
+	switch res.WinnerName() {
+	case m.username:
+		outcome = m.lang.T("duel.victory")
+		if res.Forfeit {
+			outcome = m.lang.T("duel.victory_forfeit")
+		}
+		b.WriteString(m.styles.Success.Render(outcome) + "\n\n")
+	case "":
+		b.WriteString(m.styles.Title.Render(m.lang.T("duel.draw")) + "\n\n")
+	default:
+		b.WriteString(m.styles.Error.Render(m.lang.T("duel.defeat", res.WinnerName())) + "\n\n")
+	}
+
+	help := m.styles.Help.Render(m.lang.T("common.help_return_menu"))
+	b.WriteString(help + "\n")
+}
+
+// wrap renvoie à la ligne un texte plus large que width
+func wrap(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	return lipgloss.NewStyle().Width(width).Render(s)
+}
+
+// hangingWrap renvoie à la ligne un texte précédé d'un préfixe, les lignes
+// suivantes étant alignées sous le début du texte
+func hangingWrap(prefix, s string, width int) string {
+	indent := lipgloss.Width(prefix)
+	if indent+lipgloss.Width(s) <= width {
+		return prefix + s
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, prefix, wrap(s, max(10, width-indent)))
+}
+
+// Answered retourne le nombre de questions déjà validées
+func (m QuizModel) Answered() int {
+	switch m.state {
+	case QuizStateResult:
+		return m.currentIndex + 1
+	case QuizStateFinished:
+		return len(m.questions)
+	default:
+		return m.currentIndex
+	}
+}
+
+// Total retourne le nombre de questions du quiz
+func (m QuizModel) Total() int {
+	return len(m.questions)
+}
+
+// Question retourne la i-ème question du quiz, dans l'ordre où elles sont posées
+func (m QuizModel) Question(i int) models.Question {
+	return m.questions[i]
+}
+
+// CurrentScore retourne le nombre de bonnes réponses jusqu'ici
+func (m QuizModel) CurrentScore() int {
+	return m.score
+}
+
+// SetOpponentProgress met à jour la progression de l'adversaire en duel
+func (m QuizModel) SetOpponentProgress(answered, score int) QuizModel {
+	m.opponentDone = answered
+	m.opponentScore = score
+	return m
+}
+
+// SetDuelResult enregistre le résultat final du duel
+func (m QuizModel) SetDuelResult(result lobby.Result) QuizModel {
+	m.duelResult = &result
+	return m
+}
+
 func (m QuizModel) GetScore() models.Score {
+	var duration time.Duration
+	for _, a := range m.answers {
+		duration += a.Duration
+	}
+
 	return models.Score{
 		Username: m.username,
 		Category: m.category,
 		Score:    m.score,
 		Total:    len(m.questions),
+		Duration: duration,
+	}
+}
+
+// Restore reprend une partie interrompue: les réponses déjà données sont
+// reprises et le quiz repart, après l'écran d'accueil, à la question suivante
+func (m QuizModel) Restore(answers []models.Answer) QuizModel {
+	m.answers = append([]models.Answer(nil), answers...)
+	m.score = 0
+	for _, a := range answers {
+		if a.Correct {
+			m.score++
+		}
 	}
+	m.currentIndex = min(len(answers), len(m.questions))
+	m.resumed = true
+	if m.currentIndex >= len(m.questions) {
+		m.state = QuizStateFinished
+	}
+	return m
+}
+
+// SetConfirm demande une confirmation avant de valider chaque réponse
+func (m QuizModel) SetConfirm(confirm bool) QuizModel {
+	m.confirm = confirm
+	return m
+}
+
+// IsFinished indique que toutes les questions ont été répondues (écran de fin affiché)
+func (m QuizModel) IsFinished() bool {
+	return m.state == QuizStateFinished
+}
+
+// SetUnlocked affiche les badges débloqués sur l'écran de fin
+func (m QuizModel) SetUnlocked(unlocked []achievements.Achievement) QuizModel {
+	m.unlocked = unlocked
+	return m
+}
+
+// GetAnswers retourne le détail des réponses données
+func (m QuizModel) GetAnswers() []models.Answer {
+	return m.answers
 }
 
 func (m QuizModel) IsDone() bool {
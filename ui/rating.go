@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RatingSource charge le classement par niveau d'une catégorie ("global" pour
+// le niveau toutes catégories)
+type RatingSource interface {
+	// Leaderboard retourne les meilleurs niveaux de la catégorie
+	Leaderboard(category string, limit int) ([]models.Rating, error)
+	// Rating retourne le niveau d'un joueur dans la catégorie
+	Rating(username, category string) (models.Rating, error)
+	// History retourne les dernières variations du niveau d'un joueur
+	History(username, category string, limit int) ([]models.RatingChange, error)
+}
+
+const (
+	ratingLeaderboardSize = 10
+	ratingHistorySize     = 5
+)
+
+// RatingLeaderboardModel affiche le classement par niveau (Elo) et l'historique
+// du joueur, catégorie par catégorie
+type RatingLeaderboardModel struct {
+	lang       i18n.Lang
+	styles     *Styles
+	username   string
+	categories []string // onglets: "global" puis les catégories jouées
+	category   string
+	source     RatingSource
+	ratings    []models.Rating
+	mine       models.Rating
+	history    []models.RatingChange
+	err        error
+	done       bool
+}
+
+func NewRatingLeaderboardModel(lang i18n.Lang, styles *Styles, username string, categories []string, source RatingSource) RatingLeaderboardModel {
+	m := RatingLeaderboardModel{
+		lang:       lang,
+		styles:     styles,
+		username:   username,
+		categories: append([]string{"global"}, categories...),
+		category:   "global",
+		source:     source,
+	}
+	return m.reload()
+}
+
+// reload recharge le classement et le niveau du joueur pour la catégorie
+func (m RatingLeaderboardModel) reload() RatingLeaderboardModel {
+	m.ratings, m.err = m.source.Leaderboard(m.category, ratingLeaderboardSize)
+	if m.err != nil {
+		return m
+	}
+	m.mine, m.err = m.source.Rating(m.username, m.category)
+	if m.err != nil {
+		return m
+	}
+	m.history, m.err = m.source.History(m.username, m.category, ratingHistorySize)
+	return m
+}
+
+// shiftCategory passe à l'onglet de catégorie précédent (-1) ou suivant (+1)
+func (m RatingLeaderboardModel) shiftCategory(delta int) RatingLeaderboardModel {
+	idx := 0
+	for i, c := range m.categories {
+		if c == m.category {
+			idx = i
+		}
+	}
+	idx = (idx + delta + len(m.categories)) % len(m.categories)
+	m.category = m.categories[idx]
+	return m.reload()
+}
+
+func (m RatingLeaderboardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RatingLeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", " ", "esc":
+			m.done = true
+			return m, nil
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "left", "h":
+			return m.shiftCategory(-1), nil
+		case "right", "l", "tab":
+			return m.shiftCategory(1), nil
+		}
+	}
+	return m, nil
+}
+
+func (m RatingLeaderboardModel) IsDone() bool {
+	return m.done
+}
+
+func (m RatingLeaderboardModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	title := m.lang.T("rating.title")
+	if m.category != "global" {
+		title = m.lang.T("rating.title_category", m.category)
+	}
+	b.WriteString(m.styles.Title.Render(title) + "\n")
+
+	subtitle := m.styles.Subtitle.Render(m.lang.T("rating.subtitle", m.username, m.mine.Rating))
+	b.WriteString(subtitle + "\n\n")
+
+	b.WriteString(m.renderTabs() + "\n\n")
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render(m.lang.T("rating.error")) + "\n\n")
+	} else if len(m.ratings) == 0 {
+		b.WriteString(m.styles.Error.Render(m.lang.T("rating.empty")) + "\n\n")
+		b.WriteString(m.styles.Subtitle.Render(m.lang.T("rating.empty_hint")) + "\n\n")
+	} else {
+		headerRow := fmt.Sprintf("%-5s %-20s %-10s %-10s", m.lang.T("common.col_rank"), m.lang.T("common.col_player"),
+			m.lang.T("rating.col_rating"), m.lang.T("rating.col_games"))
+		b.WriteString(m.styles.LeaderboardHeader.Render(headerRow) + "\n\n")
+
+		for i, r := range m.ratings {
+			row := fmt.Sprintf("#%-4d %-20s %-10.0f %-10d", i+1, r.Username, r.Rating, r.Games)
+
+			style := m.styles.LeaderboardRow
+			if i < 3 {
+				style = m.styles.LeaderboardTop
+			}
+			if r.Username == m.username {
+				row += " ◀"
+				style = m.styles.Highlight(style)
+			}
+			b.WriteString(style.Render("   "+row) + "\n")
+		}
+	}
+
+	// Historique du joueur
+	if len(m.history) > 0 {
+		b.WriteString("\n" + m.styles.LeaderboardHeader.Render(m.lang.T("rating.history")) + "\n\n")
+		for _, c := range m.history {
+			delta := fmt.Sprintf("%+.0f", c.Delta)
+			deltaStyle := m.styles.Success.Copy().Padding(0)
+			if c.Delta < 0 {
+				deltaStyle = m.styles.Error.Copy().Padding(0)
+			}
+			line := fmt.Sprintf("%s  %-5s %6.0f ", c.CreatedAt.Format(m.lang.T("format.datetime")), c.Source, c.Rating)
+			b.WriteString(m.styles.LeaderboardRow.Render(line) + deltaStyle.Render(delta) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	help := m.styles.Help.Render(m.lang.T("rating.help"))
+	b.WriteString(help + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+func (m RatingLeaderboardModel) renderTabs() string {
+	tabs := make([]string, len(m.categories))
+	for i, c := range m.categories {
+		label := c
+		if c == "global" {
+			label = m.lang.T("rating.tab_global")
+		}
+		if c == m.category {
+			tabs[i] = m.styles.Selected.Render(label)
+		} else {
+			tabs[i] = m.styles.Unselected.Render(label)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ResumeModel propose, à la connexion, de reprendre une partie interrompue
+type ResumeModel struct {
+	lang     i18n.Lang
+	styles   *Styles
+	progress models.Progress
+	accepted bool
+	done     bool
+}
+
+func NewResumeModel(lang i18n.Lang, styles *Styles, progress models.Progress) ResumeModel {
+	return ResumeModel{
+		lang:     lang,
+		styles:   styles,
+		progress: progress,
+	}
+}
+
+func (m ResumeModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ResumeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "y", "o", "enter":
+			m.accepted = true
+			m.done = true
+		case "n", "q", "esc":
+			m.done = true
+		}
+	}
+	return m, nil
+}
+
+func (m ResumeModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	b.WriteString(m.styles.Title.Render(m.lang.T("resume.title")) + "\n\n")
+
+	p := m.progress
+	info := m.lang.T("resume.info", p.Category, p.UpdatedAt.Local().Format(m.lang.T("format.datetime")),
+		len(p.Answers), len(p.QuestionIDs), p.Score())
+	b.WriteString(m.styles.Box.Render(m.styles.Question.Render(info)) + "\n\n")
+
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("resume.warning")) + "\n")
+	help := m.styles.Help.Render(m.lang.T("resume.help"))
+	b.WriteString(help + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+// IsAccepted indique que le joueur veut reprendre la partie (sinon elle est abandonnée)
+func (m ResumeModel) IsAccepted() bool {
+	return m.accepted
+}
+
+func (m ResumeModel) IsDone() bool {
+	return m.done
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Réglages proposés, dans l'ordre d'affichage
+const (
+	settingLanguage = iota
+	settingTheme
+	settingConfirm
+	settingMouse
+	settingCount
+)
+
+// SettingsModel permet au joueur de modifier ses préférences.
+// L'écran s'affiche aussitôt dans la langue et le thème choisis, avant même l'enregistrement.
+type SettingsModel struct {
+	lang   i18n.Lang
+	styles *Styles
+	prefs  models.Preferences
+	cursor int
+	saved  bool
+	done   bool
+}
+
+func NewSettingsModel(lang i18n.Lang, styles *Styles, prefs models.Preferences) SettingsModel {
+	return SettingsModel{
+		lang:   lang,
+		styles: styles,
+		prefs:  prefs,
+	}
+}
+
+func (m SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			m.done = true
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < settingCount-1 {
+				m.cursor++
+			}
+		case "left", "h":
+			m = m.shift(-1)
+		case "right", "l", " ":
+			m = m.shift(1)
+		case "enter":
+			m.prefs.Lang = string(m.lang)
+			m.saved = true
+			m.done = true
+		}
+	}
+	return m, nil
+}
+
+// shift passe à la valeur précédente (-1) ou suivante (+1) du réglage sélectionné
+func (m SettingsModel) shift(delta int) SettingsModel {
+	switch m.cursor {
+	case settingLanguage:
+		idx := 0
+		for i, l := range i18n.Supported {
+			if l == m.lang {
+				idx = i
+			}
+		}
+		idx = (idx + delta + len(i18n.Supported)) % len(i18n.Supported)
+		m.lang = i18n.Supported[idx]
+	case settingTheme:
+		idx := 0
+		for i, t := range Themes {
+			if t.Name == m.styles.Theme.Name {
+				idx = i
+			}
+		}
+		idx = (idx + delta + len(Themes)) % len(Themes)
+		m.styles = m.styles.WithTheme(Themes[idx])
+		// Seul un thème choisi explicitement est enregistré: sinon il reste déduit du terminal
+		m.prefs.Theme = Themes[idx].Name
+	case settingConfirm:
+		m.prefs.ConfirmAnswers = !m.prefs.ConfirmAnswers
+	case settingMouse:
+		m.prefs.Mouse = !m.prefs.Mouse
+	}
+	return m
+}
+
+func (m SettingsModel) View() string {
+	var b strings.Builder
+
+	// Header
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
+	b.WriteString(header + "\n\n")
+
+	b.WriteString(m.styles.Title.Render(m.lang.T("settings.title")) + "\n")
+	b.WriteString(m.styles.Subtitle.Render(m.lang.T("common.logged_in", m.prefs.Username)) + "\n\n")
+
+	for i := 0; i < settingCount; i++ {
+		line := fmt.Sprintf("%-16s ◀ %s ▶", m.label(i), m.value(i))
+		if i == m.cursor {
+			b.WriteString(m.styles.MenuItemSelected.Render("▶ "+line) + "\n")
+		} else {
+			b.WriteString(m.styles.MenuItem.Render("  "+line) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	help := m.styles.Help.Render(m.lang.T("settings.help"))
+	b.WriteString(help + "\n")
+
+	return m.styles.Page.Render(b.String())
+}
+
+func (m SettingsModel) label(setting int) string {
+	switch setting {
+	case settingLanguage:
+		return m.lang.T("settings.language")
+	case settingTheme:
+		return m.lang.T("settings.theme")
+	case settingConfirm:
+		return m.lang.T("settings.confirm")
+	case settingMouse:
+		return m.lang.T("settings.mouse")
+	}
+	return ""
+}
+
+func (m SettingsModel) value(setting int) string {
+	switch setting {
+	case settingLanguage:
+		return m.lang.Name()
+	case settingTheme:
+		return m.lang.T("theme." + m.styles.Theme.Name)
+	case settingConfirm:
+		return m.onOff(m.prefs.ConfirmAnswers)
+	case settingMouse:
+		return m.onOff(m.prefs.Mouse)
+	}
+	return ""
+}
+
+func (m SettingsModel) onOff(on bool) string {
+	if on {
+		return m.lang.T("settings.on")
+	}
+	return m.lang.T("settings.off")
+}
+
+// GetPreferences retourne les préférences choisies
+func (m SettingsModel) GetPreferences() models.Preferences {
+	return m.prefs
+}
+
+// GetStyles retourne les styles du thème choisi
+func (m SettingsModel) GetStyles() *Styles {
+	return m.styles
+}
+
+// IsSaved indique que le joueur a validé ses choix (sinon ils sont abandonnés)
+func (m SettingsModel) IsSaved() bool {
+	return m.saved
+}
+
+func (m SettingsModel) IsDone() bool {
+	return m.done
+}
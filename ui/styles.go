@@ -4,141 +4,259 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	// Couleurs One Dark / Dracula
-	primaryColor   = lipgloss.Color("#61afef") // Bleu clair
-	secondaryColor = lipgloss.Color("#c678dd") // Violet
-	accentColor    = lipgloss.Color("#e06c75") // Rouge/Rose
-	errorColor     = lipgloss.Color("#e06c75") // Rouge
-	successColor   = lipgloss.Color("#98c379") // Vert
-	warningColor   = lipgloss.Color("#e5c07b") // Jaune/Or
-	textColor      = lipgloss.Color("#abb2bf") // Gris clair
-	dimColor       = lipgloss.Color("#5c6370") // Gris foncé
-	bgColor        = lipgloss.Color("#282c34") // Fond dark
+// Seuils en dessous desquels les écrans passent en disposition compacte
+const (
+	compactWidth  = 70
+	compactHeight = 30
+)
+
+// Styles regroupe les styles d'une session: ils dépendent du thème choisi par
+// le joueur, du renderer de son terminal (nombre de couleurs, NO_COLOR...) et
+// de la taille de celui-ci
+type Styles struct {
+	Theme    Theme
+	Width    int // Taille du terminal, 0 si inconnue
+	Height   int
+	renderer *lipgloss.Renderer
+
+	Page lipgloss.Style
+
+	Title             lipgloss.Style
+	Subtitle          lipgloss.Style
+	Box               lipgloss.Style
+	Selected          lipgloss.Style
+	Unselected        lipgloss.Style
+	MenuItem          lipgloss.Style
+	MenuItemSelected  lipgloss.Style
+	Error             lipgloss.Style
+	Success           lipgloss.Style
+	Question          lipgloss.Style
+	Answer            lipgloss.Style
+	AnswerSelected    lipgloss.Style
+	AnswerCorrect     lipgloss.Style
+	AnswerWrong       lipgloss.Style
+	LeaderboardHeader lipgloss.Style
+	LeaderboardRow    lipgloss.Style
+	LeaderboardTop    lipgloss.Style
+	Help              lipgloss.Style
+	Stats             lipgloss.Style
+	CategoryBadge     lipgloss.Style
+	ScoreBadge        lipgloss.Style
+	Toast             lipgloss.Style
+	Banner            lipgloss.Style // Bandeau de maintenance en haut de l'écran
+	Announcement      lipgloss.Style // Bandeau des annonces aux joueurs
+	Header            lipgloss.Style
+}
+
+// NewStyles construit les styles d'un thème pour le renderer d'une session
+func NewStyles(r *lipgloss.Renderer, t Theme) *Styles {
+	return newStyles(r, t, 0, 0)
+}
+
+func newStyles(r *lipgloss.Renderer, t Theme, width, height int) *Styles {
+	// Texte sur fond coloré; en monochrome, la vidéo inverse remplace le fond
+	filled := func(fg, bg lipgloss.TerminalColor) lipgloss.Style {
+		return r.NewStyle().
+			Foreground(fg).
+			Background(bg).
+			Reverse(t.Monochrome)
+	}
+
+	s := &Styles{Theme: t, Width: width, Height: height, renderer: r}
+	compact := s.Compact()
 
 	// Styles de base
-	TitleStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true).
-			Padding(1, 2).
-			MarginBottom(1)
-
-	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Italic(true).
-			MarginBottom(1)
-
-	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(primaryColor).
-			Padding(1, 2).
-			MarginTop(1).
-			MarginBottom(1)
-
-	SelectedStyle = lipgloss.NewStyle().
-			Foreground(bgColor).
-			Background(primaryColor).
-			Bold(true).
-			Padding(0, 2)
-
-	UnselectedStyle = lipgloss.NewStyle().
-			Foreground(dimColor).
-			Padding(0, 2)
-
-	MenuItemStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Padding(0, 2)
-
-	MenuItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#282c34")).
-				Background(primaryColor).
-				Bold(true).
-				Padding(0, 2).
-				Width(50)
-
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true).
-			Padding(1)
-
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true).
-			Padding(1)
-
-	QuestionStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Bold(true).
-			Padding(1, 2).
-			MarginBottom(1)
-
-	AnswerStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Padding(0, 2)
-
-	AnswerSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#282c34")).
-				Background(warningColor).
-				Bold(true).
-				Padding(0, 2)
-
-	AnswerCorrectStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#282c34")).
-				Background(successColor).
-				Bold(true).
-				Padding(0, 2)
-
-	AnswerWrongStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#abb2bf")).
-				Background(errorColor).
-				Bold(true).
-				Padding(0, 2)
-
-	LeaderboardHeaderStyle = lipgloss.NewStyle().
-				Foreground(primaryColor).
-				Bold(true).
-				Padding(0, 1).
-				Border(lipgloss.NormalBorder(), false, false, true, false).
-				BorderForeground(dimColor)
-
-	LeaderboardRowStyle = lipgloss.NewStyle().
-				Foreground(textColor).
-				Padding(0, 1)
-
-	LeaderboardTopStyle = lipgloss.NewStyle().
-				Foreground(accentColor).
-				Bold(true).
-				Padding(0, 1)
-
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(dimColor).
-			Italic(true).
-			MarginTop(1)
-
-	StatsStyle = lipgloss.NewStyle().
-			Foreground(secondaryColor).
-			Italic(true).
-			Padding(0, 2)
-
-	CategoryBadgeStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#282c34")).
-				Background(secondaryColor).
-				Bold(true).
-				Padding(0, 1).
-				MarginRight(1)
-
-	ScoreBadgeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#282c34")).
-			Background(warningColor).
-			Bold(true).
-			Padding(0, 1)
-
-	HeaderStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true).
-			Border(lipgloss.DoubleBorder(), false, false, true, false).
-			BorderForeground(primaryColor).
-			Padding(1, 2).
-			Width(80).
-			Align(lipgloss.Center)
-)
+	s.Title = r.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	s.Subtitle = r.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true).
+		MarginBottom(1)
+
+	s.Box = r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		MarginTop(1).
+		MarginBottom(1)
+
+	s.Selected = filled(t.Background, t.Primary).
+		Bold(true).
+		Padding(0, 2)
+
+	s.Unselected = r.NewStyle().
+		Foreground(t.Dim).
+		Faint(t.Monochrome).
+		Padding(0, 2)
+
+	s.MenuItem = r.NewStyle().
+		Foreground(t.Text).
+		Padding(0, 2)
+
+	s.MenuItemSelected = filled(t.Background, t.Primary).
+		Bold(true).
+		Padding(0, 2).
+		Width(s.ContentWidth(50))
+
+	s.Error = r.NewStyle().
+		Foreground(t.Error).
+		Bold(true).
+		Padding(1)
+
+	s.Success = r.NewStyle().
+		Foreground(t.Success).
+		Bold(true).
+		Padding(1)
+
+	s.Question = r.NewStyle().
+		Foreground(t.Text).
+		Bold(true).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	s.Answer = r.NewStyle().
+		Foreground(t.Text).
+		Padding(0, 2)
+
+	s.AnswerSelected = filled(t.Background, t.Warning).
+		Bold(true).
+		Padding(0, 2)
+
+	s.AnswerCorrect = filled(t.Background, t.Success).
+		Bold(true).
+		Padding(0, 2)
+
+	s.AnswerWrong = filled(t.Text, t.Error).
+		Bold(true).
+		Padding(0, 2)
+
+	s.LeaderboardHeader = r.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(0, 1).
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(t.Dim)
+
+	s.LeaderboardRow = r.NewStyle().
+		Foreground(t.Text).
+		Padding(0, 1)
+
+	s.LeaderboardTop = r.NewStyle().
+		Foreground(t.Accent).
+		Bold(true).
+		Padding(0, 1)
+
+	s.Help = r.NewStyle().
+		Foreground(t.Dim).
+		Faint(t.Monochrome).
+		Italic(true).
+		MarginTop(1)
+
+	s.Stats = r.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true).
+		Padding(0, 2)
+
+	s.CategoryBadge = filled(t.Background, t.Secondary).
+		Bold(true).
+		Padding(0, 1).
+		MarginRight(1)
+
+	s.ScoreBadge = filled(t.Background, t.Warning).
+		Bold(true).
+		Padding(0, 1)
+
+	s.Toast = r.NewStyle().
+		Foreground(t.Warning).
+		Bold(true).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Warning).
+		Padding(0, 2)
+
+	s.Banner = filled(t.Background, t.Warning).
+		Bold(true).
+		Padding(0, 1)
+
+	s.Announcement = filled(t.Background, t.Primary).
+		Bold(true).
+		Padding(0, 1)
+
+	s.Header = r.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Border(lipgloss.DoubleBorder(), false, false, true, false).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(s.ContentWidth(80)).
+		Align(lipgloss.Center)
+
+	s.Page = r.NewStyle().
+		Padding(2)
+
+	// Petit terminal: marges et espacements réduits au minimum
+	if compact {
+		s.Page = s.Page.Padding(0, 1)
+		s.Header = s.Header.UnsetBorderStyle().UnsetBorderBottom().UnsetPadding()
+		s.Title = s.Title.Padding(0, 1).UnsetMargins()
+		s.Subtitle = s.Subtitle.UnsetMargins()
+		s.Box = s.Box.Padding(0, 1).UnsetMargins()
+		s.Question = s.Question.Padding(0, 1).UnsetMargins()
+		s.Error = s.Error.Padding(0, 1)
+		s.Success = s.Success.Padding(0, 1)
+		s.Help = s.Help.UnsetMargins()
+	}
+	// Les lignes trop longues sont coupées plutôt que renvoyées à la ligne par le terminal
+	if width > 0 {
+		s.Page = s.Page.MaxWidth(width)
+		s.Banner = s.Banner.Width(width)
+		s.Announcement = s.Announcement.Width(width)
+	}
+
+	return s
+}
+
+// Resize retourne les styles adaptés à une nouvelle taille de terminal
+func (s *Styles) Resize(width, height int) *Styles {
+	return newStyles(s.renderer, s.Theme, width, height)
+}
+
+// Compact indique que le terminal est trop petit pour la disposition complète
+func (s *Styles) Compact() bool {
+	return (s.Width > 0 && s.Width < compactWidth) || (s.Height > 0 && s.Height < compactHeight)
+}
+
+// ContentWidth retourne la largeur utilisable dans une page, au plus limit
+func (s *Styles) ContentWidth(limit int) int {
+	if s.Width <= 0 {
+		return limit
+	}
+	margin := 4
+	if s.Compact() {
+		margin = 2
+	}
+	return max(20, min(limit, s.Width-margin))
+}
+
+// WithTheme retourne les styles d'un autre thème pour le même terminal
+func (s *Styles) WithTheme(t Theme) *Styles {
+	return newStyles(s.renderer, t, s.Width, s.Height)
+}
+
+// NewStyle crée un style vierge rendu pour le terminal de la session
+func (s *Styles) NewStyle() lipgloss.Style {
+	return s.renderer.NewStyle()
+}
+
+// Color retourne un style de texte dans la couleur donnée
+func (s *Styles) Color(c lipgloss.TerminalColor) lipgloss.Style {
+	return s.NewStyle().Foreground(c)
+}
+
+// Highlight met en évidence la ligne du joueur dans un classement
+func (s *Styles) Highlight(style lipgloss.Style) lipgloss.Style {
+	return style.Foreground(s.Theme.Accent).Bold(true).Underline(s.Theme.Monochrome)
+}
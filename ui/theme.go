@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme regroupe les couleurs de l'interface
+type Theme struct {
+	Name       string // Identifiant enregistré dans les préférences
+	Primary    lipgloss.TerminalColor
+	Secondary  lipgloss.TerminalColor
+	Accent     lipgloss.TerminalColor
+	Error      lipgloss.TerminalColor
+	Success    lipgloss.TerminalColor
+	Warning    lipgloss.TerminalColor
+	Text       lipgloss.TerminalColor
+	Dim        lipgloss.TerminalColor
+	Background lipgloss.TerminalColor // Texte posé sur un fond coloré
+	Monochrome bool                   // Sans couleur: sélection en vidéo inverse
+}
+
+var (
+	// Couleurs One Dark / Dracula
+	DarkTheme = Theme{
+		Name:       "dark",
+		Primary:    lipgloss.Color("#61afef"), // Bleu clair
+		Secondary:  lipgloss.Color("#c678dd"), // Violet
+		Accent:     lipgloss.Color("#e06c75"), // Rouge/Rose
+		Error:      lipgloss.Color("#e06c75"), // Rouge
+		Success:    lipgloss.Color("#98c379"), // Vert
+		Warning:    lipgloss.Color("#e5c07b"), // Jaune/Or
+		Text:       lipgloss.Color("#abb2bf"), // Gris clair
+		Dim:        lipgloss.Color("#5c6370"), // Gris foncé
+		Background: lipgloss.Color("#282c34"), // Fond dark
+	}
+
+	// Couleurs One Light, pour les terminaux sur fond clair
+	LightTheme = Theme{
+		Name:       "light",
+		Primary:    lipgloss.Color("#4078f2"),
+		Secondary:  lipgloss.Color("#a626a4"),
+		Accent:     lipgloss.Color("#e45649"),
+		Error:      lipgloss.Color("#ca1243"),
+		Success:    lipgloss.Color("#50a14f"),
+		Warning:    lipgloss.Color("#c18401"),
+		Text:       lipgloss.Color("#383a42"),
+		Dim:        lipgloss.Color("#696c77"),
+		Background: lipgloss.Color("#fafafa"),
+	}
+
+	// Couleurs ANSI vives, lisibles quelle que soit la palette du terminal
+	HighContrastTheme = Theme{
+		Name:       "high-contrast",
+		Primary:    lipgloss.Color("14"), // Cyan vif
+		Secondary:  lipgloss.Color("13"), // Magenta vif
+		Accent:     lipgloss.Color("11"), // Jaune vif
+		Error:      lipgloss.Color("9"),  // Rouge vif
+		Success:    lipgloss.Color("10"), // Vert vif
+		Warning:    lipgloss.Color("11"), // Jaune vif
+		Text:       lipgloss.Color("15"), // Blanc
+		Dim:        lipgloss.Color("7"),  // Gris clair
+		Background: lipgloss.Color("0"),  // Noir
+	}
+
+	// Aucune couleur: l'emphase passe par le gras et la vidéo inverse
+	MonochromeTheme = Theme{
+		Name:       "monochrome",
+		Primary:    lipgloss.NoColor{},
+		Secondary:  lipgloss.NoColor{},
+		Accent:     lipgloss.NoColor{},
+		Error:      lipgloss.NoColor{},
+		Success:    lipgloss.NoColor{},
+		Warning:    lipgloss.NoColor{},
+		Text:       lipgloss.NoColor{},
+		Dim:        lipgloss.NoColor{},
+		Background: lipgloss.NoColor{},
+		Monochrome: true,
+	}
+)
+
+// Themes liste les thèmes proposés aux joueurs, dans l'ordre d'affichage
+var Themes = []Theme{DarkTheme, LightTheme, HighContrastTheme, MonochromeTheme}
+
+// ThemeByName retrouve un thème par son identifiant
+func ThemeByName(name string) (Theme, bool) {
+	for _, t := range Themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
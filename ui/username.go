@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"quizz-ssh/i18n"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -10,20 +12,24 @@ import (
 )
 
 type UsernameModel struct {
+	lang      i18n.Lang
+	styles    *Styles
 	textInput textinput.Model
 	err       error
 	username  string
 	done      bool // Indique si l'utilisateur a validé son pseudo
 }
 
-func NewUsernameModel() UsernameModel {
+func NewUsernameModel(lang i18n.Lang, styles *Styles) UsernameModel {
 	ti := textinput.New()
-	ti.Placeholder = "Ton pseudo..."
+	ti.Placeholder = lang.T("username.placeholder")
 	ti.Focus()
 	ti.CharLimit = 20
 	ti.Width = 30
 
 	return UsernameModel{
+		lang:      lang,
+		styles:    styles,
 		textInput: ti,
 	}
 }
@@ -36,6 +42,8 @@ func (m UsernameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.styles = m.styles.Resize(msg.Width, msg.Height)
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyEnter:
@@ -45,7 +53,7 @@ func (m UsernameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.done = true
 				return m, nil // Ne pas quitter, juste marquer comme done
 			}
-			m.err = fmt.Errorf("le pseudo doit faire au moins 3 caractères")
+			m.err = errors.New(m.lang.T("username.too_short"))
 			return m, nil
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit // Vraiment quitter uniquement si Ctrl+C
@@ -60,33 +68,33 @@ func (m UsernameModel) View() string {
 	var b strings.Builder
 
 	// Header avec ASCII art
-	header := HeaderStyle.Render("🔐 CYBERSEC QUIZ 🔐")
+	header := m.styles.Header.Render("🔐 CYBERSEC QUIZ 🔐")
 	b.WriteString(header + "\n\n")
 
 	// Welcome message
-	welcome := TitleStyle.Render("Bienvenue sur le quiz de cybersécurité !")
+	welcome := m.styles.Title.Render(m.lang.T("username.welcome"))
 	b.WriteString(welcome + "\n")
 
-	subtitle := SubtitleStyle.Render("Entre ton pseudo pour commencer")
+	subtitle := m.styles.Subtitle.Render(m.lang.T("username.prompt"))
 	b.WriteString(subtitle + "\n\n")
 
 	// Input box
-	box := lipgloss.NewStyle().
+	box := m.styles.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(primaryColor).
+		BorderForeground(m.styles.Theme.Primary).
 		Padding(1, 2).
-		Width(50).
+		Width(m.styles.ContentWidth(50)).
 		Align(lipgloss.Center)
 
 	b.WriteString(box.Render(m.textInput.View()) + "\n\n")
 
 	// Error message
 	if m.err != nil {
-		b.WriteString(ErrorStyle.Render(fmt.Sprintf("❌ %s", m.err.Error())) + "\n\n")
+		b.WriteString(m.styles.Error.Render(fmt.Sprintf("❌ %s", m.err.Error())) + "\n\n")
 	}
 
 	// Help text
-	help := HelpStyle.Render("enter: valider • ctrl+c: quitter")
+	help := m.styles.Help.Render(m.lang.T("username.help"))
 	b.WriteString(help + "\n")
 
 	return b.String()
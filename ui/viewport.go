@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"quizz-ssh/i18n"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Viewport affiche la partie visible d'un écran plus haut que le terminal.
+// Il suit la ligne sélectionnée (celle qui commence par ▶) et défile avec
+// shift+↑/↓; la dernière ligne indique la position dans l'écran.
+type Viewport struct {
+	offset   int
+	selected int // ligne sélectionnée lors du dernier suivi, -1 si aucune
+}
+
+// Scroll décale la fenêtre de delta lignes dans content
+func (v Viewport) Scroll(content string, height, delta int) Viewport {
+	v.offset = max(0, min(v.offset+delta, maxOffset(content, height)))
+	return v
+}
+
+// Follow ramène la ligne sélectionnée dans la fenêtre quand elle a changé,
+// sans contrarier un défilement manuel tant que la sélection reste la même
+func (v Viewport) Follow(content string, height int) Viewport {
+	line := selectedLine(content)
+	if line < 0 || line == v.selected {
+		v.selected = line
+		return v
+	}
+	v.selected = line
+
+	// Sélection hors de la fenêtre: la centrer, pour montrer aussi la suite
+	// d'une option renvoyée à la ligne
+	visible := height - 1
+	if line < v.offset || line >= v.offset+visible {
+		v.offset = line - visible/2
+	}
+	v.offset = max(0, min(v.offset, maxOffset(content, height)))
+	return v
+}
+
+// Offset retourne l'index de la première ligne de content affichée
+func (v Viewport) Offset(content string, height int) int {
+	lines := strings.Count(content, "\n") + 1
+	if height <= 1 || lines <= height {
+		return 0
+	}
+	return min(v.offset, lines-(height-1))
+}
+
+// View retourne les lignes visibles de content suivies de l'indicateur de défilement
+func (v Viewport) View(content string, height int, lang i18n.Lang, styles *Styles) string {
+	lines := strings.Split(content, "\n")
+	if height <= 1 || len(lines) <= height {
+		return content
+	}
+
+	visible := height - 1
+	offset := v.Offset(content, height)
+	indicator := styles.Help.UnsetMargins().Render(" " + lang.T("common.scroll", offset+1, offset+visible, len(lines)))
+	return strings.Join(lines[offset:offset+visible], "\n") + "\n" + indicator
+}
+
+func maxOffset(content string, height int) int {
+	return max(0, strings.Count(content, "\n")+1-(height-1))
+}
+
+// selectedLine retourne l'index de la première ligne sélectionnée, -1 si aucune
+func selectedLine(content string) int {
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(ansi.Strip(line)), "▶") {
+			return i
+		}
+	}
+	return -1
+}
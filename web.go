@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"quizz-ssh/api"
+	"quizz-ssh/models"
+	"quizz-ssh/storage"
+	"quizz-ssh/web"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// apiTokensPath liste les jetons d'accès à l'API d'administration, un par ligne
+const apiTokensPath = "./data/api_tokens"
+
+// scoreEvents prévient la page web des classements à chaque partie enregistrée
+// ou supprimée. Avec une base partagée entre plusieurs instances, seules les
+// parties jouées sur cette instance rafraîchissent ses pages.
+var scoreEvents = web.NewHub()
+
+// notifyingStore enrichit le stockage des notifications de scoreEvents
+type notifyingStore struct {
+	storage.Store
+}
+
+func (s notifyingStore) SaveAttempt(score models.Score, answers []models.Answer) (int, error) {
+	id, err := s.Store.SaveAttempt(score, answers)
+	if err == nil {
+		scoreEvents.Notify()
+	}
+	return id, err
+}
+
+func (s notifyingStore) DeleteScore(id int) error {
+	err := s.Store.DeleteScore(id)
+	if err == nil {
+		scoreEvents.Notify()
+	}
+	return err
+}
+
+// startHTTP démarre le serveur HTTP (page des classements et API JSON) sur
+// addr, à côté du serveur SSH
+func startHTTP(addr string) (*http.Server, error) {
+	tokens, err := loadTokens(getenv("API_TOKENS_FILE", apiTokensPath))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api.New(db, tokens))
+	mux.Handle("/", web.New(db, scoreEvents))
+
+	srv := newHTTPServer(addr, mux)
+	// Les flux SSE ne se terminent pas d'eux-mêmes: les fermer pour que l'arrêt n'attende pas
+	srv.RegisterOnShutdown(scoreEvents.Close)
+	go listenHTTP(srv)
+	log.Printf("🌐 Classements et API HTTP sur %s (%d jeton(s) d'administration)", addr, len(tokens))
+	return srv, nil
+}
+
+// startMetrics sert les métriques Prometheus sur un port à part (METRICS_ADDR),
+// à réserver au réseau interne
+func startMetrics(addr, path string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+path, promhttp.Handler())
+
+	srv := newHTTPServer(addr, mux)
+	go listenHTTP(srv)
+	log.Printf("📈 Métriques Prometheus sur %s%s", addr, path)
+	return srv
+}
+
+func newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+func listenHTTP(srv *http.Server) {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Erreur serveur HTTP: %v", err)
+	}
+}
+
+// stopHTTP arrête le serveur HTTP en laissant les requêtes en cours se terminer
+func stopHTTP(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownMargin)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("⚠️  Arrêt du serveur HTTP: %v", err)
+	}
+}
+
+// loadTokens lit les jetons de l'API d'administration; un fichier absent
+// signifie aucun jeton: les routes d'administration restent fermées
+func loadTokens(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	return tokens, scanner.Err()
+}
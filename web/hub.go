@@ -0,0 +1,58 @@
+package web
+
+import "sync"
+
+// Hub prévient les pages ouvertes qu'un classement a changé. Les notifications
+// rapprochées sont regroupées: un abonné en retard n'en reçoit qu'une.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[chan struct{}]bool
+	closed chan struct{}
+	once   sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[chan struct{}]bool), closed: make(chan struct{})}
+}
+
+// Notify prévient tous les abonnés, sans jamais bloquer
+func (h *Hub) Notify() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribe abonne une page; cancel la désabonne
+func (h *Hub) Subscribe() (updates <-chan struct{}, cancel func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	h.subs[ch] = true
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs, ch)
+		h.mu.Unlock()
+	}
+}
+
+// Count retourne le nombre de pages abonnées
+func (h *Hub) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Close termine tous les flux en cours, à l'arrêt du serveur
+func (h *Hub) Close() {
+	h.once.Do(func() { close(h.closed) })
+}
+
+// Done est fermé à l'arrêt du serveur
+func (h *Hub) Done() <-chan struct{} {
+	return h.closed
+}
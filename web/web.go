@@ -0,0 +1,266 @@
+package web
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"quizz-ssh/achievements"
+	"quizz-ssh/i18n"
+	"quizz-ssh/models"
+	"strconv"
+	"time"
+)
+
+// Nombre de joueurs affichés par défaut et au maximum (?limit=)
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// keepAliveInterval espace les commentaires envoyés sur un flux inactif, pour
+// que les proxys ne le coupent pas
+const keepAliveInterval = 30 * time.Second
+
+// periods liste les onglets de période, dans l'ordre d'affichage
+var periods = []models.Period{models.PeriodDay, models.PeriodWeek, models.PeriodMonth, models.PeriodAll}
+
+//go:embed leaderboard.html
+var templates embed.FS
+
+var page = template.Must(template.New("leaderboard.html").Funcs(template.FuncMap{
+	"t": func(lang i18n.Lang, key string, args ...any) string { return lang.T(key, args...) },
+}).ParseFS(templates, "leaderboard.html"))
+
+// Source fournit les classements affichés par la page
+type Source interface {
+	GetLeaderboard(category string, period models.Period, limit int) ([]models.Score, error)
+	GetCategories() ([]string, error)
+	GetStats() (games, players int, err error)
+	GetAchievementCodes(usernames []string) (map[string][]string, error)
+}
+
+// Server sert la page publique des classements, rafraîchie par Server-Sent
+// Events à chaque notification du hub
+type Server struct {
+	source Source
+	hub    *Hub
+	mux    *http.ServeMux
+}
+
+func New(source Source, hub *Hub) *Server {
+	s := &Server{source: source, hub: hub, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /{$}", s.leaderboard)
+	s.mux.HandleFunc("GET /events", s.events)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// link est un onglet de la page (période ou catégorie)
+type link struct {
+	Label  string
+	URL    string
+	Active bool
+}
+
+// row est une ligne du classement
+type row struct {
+	Rank     int
+	Medal    string
+	Username string
+	Badges   string
+	Score    int
+	Total    int
+	Rate     string
+	Time     string
+}
+
+type pageData struct {
+	Lang       i18n.Lang
+	Title      string
+	Periods    []link
+	Categories []link
+	Rows       []row
+	Games      int
+	Players    int
+	Failed     bool
+	Updated    string
+}
+
+// leaderboard affiche un classement: ?category=&period=all|day|week|month&limit=&lang=
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	period, ok := models.ParsePeriod(query.Get("period"))
+	if !ok {
+		http.Error(w, "période inconnue (all, day, week, month)", http.StatusBadRequest)
+		return
+	}
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit invalide", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxLimit)
+	}
+	category := query.Get("category")
+	lang := requestLang(r)
+
+	data := pageData{Lang: lang, Title: lang.T("leaderboard.title_global")}
+	if category != "" {
+		data.Title = lang.T("leaderboard.title_category", category)
+	}
+
+	var err error
+	data.Rows, err = s.rows(category, period, limit)
+	if err == nil {
+		data.Games, data.Players, err = s.source.GetStats()
+	}
+	var categories []string
+	if err == nil {
+		categories, err = s.source.GetCategories()
+	}
+	if err != nil {
+		log.Printf("Erreur page des classements: %v", err)
+		data.Failed = true
+	}
+
+	for _, p := range periods {
+		data.Periods = append(data.Periods, link{
+			Label:  p.Label(lang),
+			URL:    pageURL(query, category, p),
+			Active: p == period,
+		})
+	}
+	data.Categories = append(data.Categories, link{
+		Label:  lang.T("web.global"),
+		URL:    pageURL(query, "", period),
+		Active: category == "",
+	})
+	for _, c := range categories {
+		data.Categories = append(data.Categories, link{Label: c, URL: pageURL(query, c, period), Active: c == category})
+	}
+	data.Updated = lang.T("web.updated", time.Now().Format("15:04:05"))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, data); err != nil {
+		log.Printf("Erreur rendu page des classements: %v", err)
+	}
+}
+
+// rows charge le classement avec les badges de chaque joueur
+func (s *Server) rows(category string, period models.Period, limit int) ([]row, error) {
+	scores, err := s.source.GetLeaderboard(category, period, limit)
+	if err != nil {
+		return nil, err
+	}
+	usernames := make([]string, len(scores))
+	for i, score := range scores {
+		usernames[i] = score.Username
+	}
+	badges, err := s.source.GetAchievementCodes(usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]row, len(scores))
+	for i, score := range scores {
+		rate := 0.0
+		if score.Total > 0 {
+			rate = float64(score.Score) / float64(score.Total) * 100
+		}
+		rows[i] = row{
+			Rank:     score.Rank,
+			Medal:    medal(score.Rank),
+			Username: score.Username,
+			Badges:   achievements.Icons(badges[score.Username]),
+			Score:    score.Score,
+			Total:    score.Total,
+			Rate:     fmt.Sprintf("%.1f%%", rate),
+			Time:     models.FormatDuration(score.Duration),
+		}
+	}
+	return rows, nil
+}
+
+// events envoie un événement "scores" à chaque changement des classements
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "flux non supporté", http.StatusInternalServerError)
+		return
+	}
+	updates, cancel := s.hub.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Pas de mise en tampon derrière nginx
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.hub.Done():
+			return
+		case <-updates:
+			fmt.Fprint(w, "event: scores\ndata: {}\n\n")
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// requestLang choisit la langue de la page: ?lang=, sinon Accept-Language
+func requestLang(r *http.Request) i18n.Lang {
+	if lang, ok := i18n.Parse(r.URL.Query().Get("lang")); ok {
+		return lang
+	}
+	if lang, ok := i18n.Parse(r.Header.Get("Accept-Language")); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// pageURL construit le lien d'un onglet en gardant les autres paramètres (lang, limit)
+func pageURL(query url.Values, category string, period models.Period) string {
+	values := url.Values{}
+	for key, v := range query {
+		values[key] = v
+	}
+	values.Del("category")
+	values.Del("period")
+	if category != "" {
+		values.Set("category", category)
+	}
+	if period != models.PeriodAll {
+		values.Set("period", period.Name())
+	}
+	if len(values) == 0 {
+		return "/"
+	}
+	return "/?" + values.Encode()
+}
+
+func medal(rank int) string {
+	switch rank {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	}
+	return ""
+}